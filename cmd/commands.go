@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type Command string
+
+const (
+	CommandPlay     Command = "play"
+	CommandList     Command = "list"
+	CommandValidate Command = "validate"
+	CommandStats    Command = "stats"
+	CommandServe    Command = "serve"
+	CommandHelp     Command = "help"
+)
+
+type ListTopic string
+
+const (
+	ListCategories   ListTopic = "categories"
+	ListDifficulties ListTopic = "difficulties"
+)
+
+// Exit codes of the program.
+const (
+	ExitSuccess = 0
+	ExitFailure = 1
+	// ExitUsage is returned when the command line itself is wrong, e.g. an unknown command.
+	ExitUsage = 2
+	// ExitBadInput is returned when a value is not available, e.g. a category missing from the word pack in the strict mode.
+	ExitBadInput = 3
+	// ExitInterrupted is returned when the program is stopped by SIGINT or SIGTERM, as shells do for SIGINT.
+	ExitInterrupted = 130
+)
+
+const programName = "hangman"
+
+type commandHelp struct {
+	usage       string
+	description string
+}
+
+// commands are listed in the help in this order.
+var commands = []Command{CommandPlay, CommandList, CommandValidate, CommandStats, CommandServe, CommandHelp}
+
+var commandHelps = map[Command]commandHelp{
+	CommandPlay: {
+		usage:       "play [flags]",
+		description: "Play a game in the terminal. This is the default command, so the flags can be given without it.",
+	},
+	CommandList: {
+		usage:       "list <categories|difficulties> [flags]",
+		description: "List the categories or difficulties of the word pack with the number of words in each.",
+	},
+	CommandValidate: {
+		usage:       "validate [flags] [word pack files]",
+		description: "Check word packs against the schema, the default pack of the language when no files are given.",
+	},
+	CommandStats: {
+		usage:       "stats",
+		description: "Show the results of the games played in the terminal.",
+	},
+	CommandServe: {
+		usage:       "serve [flags]",
+		description: "Host a multiplayer room over TCP (--host) and the live game page (--serve, " + defaultServeAddress + " by default).",
+	},
+	CommandHelp: {
+		usage:       "help [command]",
+		description: "Show the help of a command.",
+	},
+}
+
+// ParseListTopic returns what the list command should list, reporting whether the topic is known.
+func ParseListTopic(args []string) (ListTopic, bool) {
+	if len(args) != 1 {
+		return "", false
+	}
+
+	topic := ListTopic(strings.ToLower(args[0]))
+
+	return topic, topic == ListCategories || topic == ListDifficulties
+}
+
+// PrintHelp prints the help of the command named in args, or the list of commands when args are empty.
+func PrintHelp(args []string) bool {
+	if len(args) == 0 {
+		printOverview()
+		return true
+	}
+
+	command := Command(args[0])
+	if _, exists := commandHelps[command]; !exists {
+		PrintUnknownCommand(command)
+		return false
+	}
+
+	newFlagSet(command, &flagValues{}).Usage()
+
+	return true
+}
+
+// PrintUnknownCommand tells the user that the command does not exist and lists the existing ones.
+func PrintUnknownCommand(command Command) {
+	fmt.Fprintf(os.Stderr, "Unknown command %q.\n\n", command)
+	printOverview()
+}
+
+// PrintUsage prints the help of the command, e.g. after its arguments turned out to be wrong.
+func PrintUsage(command Command) {
+	newFlagSet(command, &flagValues{}).Usage()
+}
+
+// splitCommand separates the command from its arguments; arguments starting with a flag belong to play.
+func splitCommand(args []string) (Command, []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return CommandPlay, args
+	}
+
+	return Command(args[0]), args[1:]
+}
+
+// parseInterspersed parses flags given before, between and after the positional arguments and returns the latter.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// newFlagSet returns the flags of the command bound to values. Play and serve have all flags of the game,
+// the other commands only the few of them they need.
+func newFlagSet(command Command, values *flagValues) *flag.FlagSet {
+	flags := flag.NewFlagSet(programName+" "+string(command), flag.ContinueOnError)
+
+	switch command {
+	case CommandPlay, CommandServe:
+		addGameFlags(flags, values)
+		addNetworkFlags(flags, &values.network)
+	case CommandList:
+		flags.StringVar(&values.difficulty, "difficulty", "", "List only the categories of this difficulty")
+		flags.StringVar(&values.words, "words", "", "Path to a word pack JSON file, by default the pack in the interface language is used")
+		flags.StringVar(&values.lang, "lang", "", "Language of the output and the default word pack (en, ru)")
+	case CommandValidate:
+		flags.StringVar(&values.lang, "lang", "", "Language of the output and the default word pack (en, ru)")
+	case CommandStats, CommandHelp:
+	}
+
+	addCommonFlags(flags, values)
+
+	flags.Usage = func() {
+		help := commandHelps[command]
+		fmt.Fprintf(flags.Output(), "Usage: %s %s\n\n%s\n", programName, help.usage, help.description)
+
+		fmt.Fprint(flags.Output(), "\nFlags:\n")
+		flags.PrintDefaults()
+
+		if command == CommandPlay {
+			fmt.Fprintln(flags.Output())
+			printCommands()
+		}
+	}
+
+	return flags
+}
+
+func printOverview() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [command] [flags]\n\n", programName)
+	printCommands()
+}
+
+func printCommands() {
+	fmt.Fprint(os.Stderr, "Commands:\n")
+
+	for _, command := range commands {
+		fmt.Fprintf(os.Stderr, "  %-40s %s\n", commandHelps[command].usage, firstSentence(commandHelps[command].description))
+	}
+
+	fmt.Fprintf(os.Stderr, "\nRun \"%s help <command>\" to see the flags of a command.\n", programName)
+}
+
+func firstSentence(text string) string {
+	if end := strings.Index(text, ". "); end >= 0 {
+		return text[:end+1]
+	}
+
+	return text
+}
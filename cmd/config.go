@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// envPrefix starts the names of the environment variables that set flags, e.g. HANGMAN_DIFFICULTY for --difficulty.
+const envPrefix = "HANGMAN_"
+
+type LogOptions struct {
+	FilePath string
+	Level    slog.Level
+}
+
+// logOptions returns where the program logs and from which level.
+func (values *flagValues) logOptions() LogOptions {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(values.logLevel)); err != nil {
+		slog.Info(
+			"Log level not found in list of available values, so the default value is set - info",
+			slog.String("log-level", values.logLevel),
+		)
+
+		level = slog.LevelInfo
+	}
+
+	return LogOptions{
+		FilePath: values.logFile,
+		Level:    level,
+	}
+}
+
+// addCommonFlags adds the flags every command has.
+func addCommonFlags(flags *flag.FlagSet, values *flagValues) {
+	flags.StringVar(&values.config, "config", "",
+		"Path to a TOML config file, by default ~/.config/hangman/config.toml is used if it exists")
+	flags.StringVar(&values.logFile, "log-file", "", "Path to the log file, var/logs.log by default")
+	flags.StringVar(&values.statsFile, "stats-file", "", "Path to the statistics file, var/stats.jsonl by default")
+	flags.StringVar(&values.logLevel, "log-level", "info", "Lowest level of the logged records (debug, info, warn, error)")
+}
+
+// applySettings sets the flags missing from the command line to the values of HANGMAN_* environment variables
+// or, when those are missing too, of the config file. Flags given on the command line always win,
+// and an empty variable, e.g. HANGMAN_ATTEMPTS=, counts as missing.
+func applySettings(flags *flag.FlagSet, values *flagValues) error {
+	settings, err := loadConfig(values.config)
+	if err != nil {
+		return err
+	}
+
+	given := make(map[string]bool)
+
+	flags.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+
+	var errs []error
+
+	flags.VisitAll(func(f *flag.Flag) {
+		if given[f.Name] || f.Name == "config" {
+			return
+		}
+
+		value, source := settings[f.Name], "config file"
+
+		if envValue := os.Getenv(EnvName(f.Name)); envValue != "" {
+			value, source = envValue, EnvName(f.Name)
+		} else if _, exists := settings[f.Name]; !exists {
+			return
+		}
+
+		if err := flags.Set(f.Name, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for %s from %s: %w", value, f.Name, source, err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// loadConfig returns the settings of the config file by flag name. The file is taken from --config,
+// then from HANGMAN_CONFIG and then from the user config directory, where it may be missing.
+func loadConfig(path string) (map[string]string, error) {
+	optional := false
+
+	if path == "" {
+		path = os.Getenv(EnvName("config"))
+	}
+
+	if path == "" {
+		defaultPath, err := DefaultConfigPath()
+		if err != nil {
+			// Without a config directory there is no default config to load.
+			return map[string]string{}, nil
+		}
+
+		path, optional = defaultPath, true
+	}
+
+	config, err := LoadConfigFile(path, optional)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	known := newFlagSet(CommandPlay, &flagValues{})
+	settings := make(map[string]string, len(config))
+
+	for key, value := range config {
+		// Keys of tables name flags with a dash, e.g. level in [log] sets --log-level.
+		name := strings.ReplaceAll(key, ".", "-")
+
+		if name == "config" || known.Lookup(name) == nil {
+			return nil, fmt.Errorf("loading config: unknown setting %q in %s", key, path)
+		}
+
+		settings[name] = value
+	}
+
+	return settings, nil
+}
+
+// EnvName returns the name of the environment variable that sets the flag.
+func EnvName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	configDirName  = "hangman"
+	configFileName = "config.toml"
+)
+
+// DefaultConfigPath returns the path of the config file in the user config directory, e.g. ~/.config/hangman/config.toml.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("getting user config directory: %w", err)
+	}
+
+	return filepath.Join(dir, configDirName, configFileName), nil
+}
+
+// LoadConfigFile reads the settings of the TOML config file at path. Keys of a table are prefixed with its name
+// and a dot, e.g. "log.level". When optional is set, a missing file has no settings instead of being an error.
+// The config is read before the logger is set up, so errors are returned without being logged.
+func LoadConfigFile(path string, optional bool) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if optional && errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	settings, err := parseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return settings, nil
+}
+
+// parseConfig decodes the TOML config into the text each setting sets a flag with. Keys of tables,
+// including inline ones, are prefixed with the table name and a dot.
+func parseConfig(data []byte) (map[string]string, error) {
+	var document map[string]any
+	if _, err := toml.Decode(string(data), &document); err != nil {
+		return nil, fmt.Errorf("decoding TOML: %w", err)
+	}
+
+	settings := make(map[string]string)
+	if err := flattenConfig(settings, "", document); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// flattenConfig adds the values of table to settings; each flag takes a single value, so arrays are rejected.
+func flattenConfig(settings map[string]string, prefix string, table map[string]any) error {
+	for key, value := range table {
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flattenConfig(settings, prefix+key+".", value); err != nil {
+				return err
+			}
+		case string:
+			settings[prefix+key] = value
+		case int64:
+			settings[prefix+key] = strconv.FormatInt(value, 10)
+		case float64:
+			settings[prefix+key] = strconv.FormatFloat(value, 'g', -1, 64)
+		case bool:
+			settings[prefix+key] = strconv.FormatBool(value)
+		default:
+			return fmt.Errorf("unsupported value of %q, expected a string, a number or a boolean", prefix+key)
+		}
+	}
+
+	return nil
+}
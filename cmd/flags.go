@@ -1,67 +1,339 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 )
 
-var (
-	difficulty = flag.String("difficulty", "random", "Game difficulty level (random, easy, hard)")
-	category   = flag.String("category", "random", "Category of words to use (random, Animals, Fruits, Cars, Cities, Countries, Hobbies)")
-)
+// GameConfig is the configuration of the program taken from its command line, the environment and the config file.
+type GameConfig struct {
+	Command Command
+	// Args are the arguments left after the flags, e.g. the topic of the list command.
+	Args []string
+	// Difficulty and Category are empty when they should be picked at random.
+	Difficulty domain.Difficulty
+	Category   domain.Category
+	// Strict fails on a difficulty or category missing from the word pack instead of picking a random one.
+	Strict bool
+	// Language is the value of --lang, empty when the language should be detected from the environment.
+	Language   string
+	Fullscreen bool
+	// Renderer and Theme are the names of the renderer and the color theme, which the application resolves,
+	// as the renderer and whether colors are shown depend on the terminal.
+	Renderer string
+	// Art is the name or path of the art pack, empty when the built-in gallows should be used.
+	Art   string
+	Theme string
+	// Attempts is zero when they depend on the difficulty.
+	Attempts   int
+	HintPolicy domain.HintPolicy
+	Filter     domain.WordFilter
+	Selection  domain.SelectionStrategy
+	// Weights take precedence over the weights of the word pack; nil maps leave those of the pack.
+	Weights domain.SelectionWeights
+	// TurnTime and GameTime limit the time of a guess and of the whole game, zero when there is no limit.
+	TurnTime time.Duration
+	GameTime time.Duration
+	// Blitz plays words back to back, sharing the attempts and the game time between them.
+	Blitz bool
+	// WordPack is the path to the word pack, empty when the default pack should be used.
+	WordPack string
+	// StatsPath is the path to the statistics file, empty when the default file should be used.
+	StatsPath string
+	Network   NetworkOptions
+	Log       LogOptions
+}
 
-func ParseFlag(dwp *domain.DefaultWordProvider) (domain.Category, domain.Difficulty, error) {
-	flag.Parse()
+// flagValues are the raw values of the flags, before they are turned into a GameConfig.
+type flagValues struct {
+	difficulty  string
+	category    string
+	strict      bool
+	lang        string
+	fullscreen  bool
+	accessible  bool
+	renderer    string
+	art         string
+	theme       string
+	attempts    int
+	hint        string
+	turnTime    time.Duration
+	gameTime    time.Duration
+	blitz       bool
+	minLength   int
+	maxLength   int
+	exclude     string
+	tag         string
+	spaces      bool
+	selection   string
+	diffWeights string
+	ctgWeights  string
+	words       string
+	network     networkValues
+	config      string
+	logFile     string
+	statsFile   string
+	logLevel    string
+}
 
-	inputCategory := domain.Category(strings.ToLower(*category))
-	inputDifficulty := domain.Difficulty(strings.ToLower(*difficulty))
+// Parse parses the arguments of the program, without the program name, into a GameConfig. Flags missing from args
+// are taken from HANGMAN_* environment variables and then from the config file. It returns flag.ErrHelp when the help
+// was requested and the error of the flag package when args are wrong; both are printed with the usage of the command.
+// Values of the environment or config file that can not be applied are returned as an error together with the config.
+// With --strict, so are options set to values the game can not use, as domain.UnknownValueError.
+func Parse(args []string) (*GameConfig, error) {
+	command, args := splitCommand(args)
+	values := &flagValues{}
 
-	if !slices.Contains(dwp.AllDifficulties, inputDifficulty) {
-		slog.Info(
-			"Difficulty not found in list of available values or flags is missing, so the default value is set - random",
-			slog.String("difficulty", string(inputDifficulty)),
-		)
+	if _, exists := commandHelps[command]; !exists {
+		return &GameConfig{Command: command, Args: args}, nil
+	}
 
-		randomDifficulty, err := dwp.GetRandomDifficulty()
-		if err != nil {
-			slog.Error(
-				"getting random difficulty",
-				slog.String("error", err.Error()),
-			)
+	flags := newFlagSet(command, values)
+
+	positional, err := parseInterspersed(flags, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySettings(flags, values); err != nil {
+		config, _ := values.gameConfig(command, positional)
+		return config, err
+	}
+
+	return values.gameConfig(command, positional)
+}
+
+// addGameFlags adds the flags of a game played in the terminal or hosted as a room.
+func addGameFlags(flags *flag.FlagSet, values *flagValues) {
+	flags.StringVar(&values.difficulty, "difficulty", domain.RandomValue,
+		"Game difficulty level (random, easy, medium, hard), see the list command")
+	flags.StringVar(&values.category, "category", domain.RandomValue,
+		"Category of words to use (random, animals, fruits, ...), see the list command")
+	flags.BoolVar(&values.strict, "strict", false,
+		"Fail on a difficulty or category missing from the word pack instead of picking a random one")
+	flags.StringVar(&values.lang, "lang", "",
+		"Language of the interface and the default word pack (en, ru), detected from LANG when missing")
+	flags.BoolVar(&values.fullscreen, "fullscreen", false,
+		"Redraw the game in place and read single keypresses when running in a terminal")
+	flags.IntVar(&values.attempts, "attempts", 0,
+		"Number of attempts, by default it depends on the difficulty (easy 9, medium 7, hard 5)")
+	flags.StringVar(&values.hint, "hint", "half", "Hint policy (half, never, on-demand, after:N, reveal)")
+	flags.DurationVar(&values.turnTime, "turn-time", 0, "Time to make a guess, e.g. 20s; a guess not made in time costs an attempt")
+	flags.DurationVar(&values.gameTime, "game-time", 0, "Time to guess the word, e.g. 3m, shown as a countdown")
+	flags.BoolVar(&values.blitz, "blitz", false,
+		"Play words back to back until one is lost or --game-time runs out, carrying the attempts left over")
+	flags.IntVar(&values.minLength, "min-length", 0, "Play only words with at least this many letters")
+	flags.IntVar(&values.maxLength, "max-length", 0, "Play only words with at most this many letters")
+	flags.StringVar(&values.exclude, "exclude-letters", "", "Play only words without any of these letters")
+	flags.StringVar(&values.tag, "tag", "", "Play only words with this tag from the word pack")
+	flags.BoolVar(&values.spaces, "words-with-spaces", true, "Play phrases with spaces as well as single words")
+	flags.StringVar(&values.selection, "selection", string(domain.SelectionUniformCategories),
+		"How random difficulties and categories are picked (categories, words, weights)")
+	flags.StringVar(&values.diffWeights, "difficulty-weights", "",
+		"Weights of difficulties for --selection weights, e.g. hard=2,easy=0, instead of those of the word pack")
+	flags.StringVar(&values.ctgWeights, "category-weights", "",
+		"Weights of categories for --selection weights, e.g. animals=3,cars=0, instead of those of the word pack")
+	flags.StringVar(&values.words, "words", "",
+		"Path to a word pack JSON file, by default the pack in the interface language is used")
+	flags.BoolVar(&values.accessible, "accessible", false,
+		"Describe the game in plain sentences for screen readers instead of drawing it")
+	flags.StringVar(&values.renderer, "renderer", "box", "How the game is drawn in the line mode (box, plain, json)")
+	flags.StringVar(&values.art, "art", "",
+		"Gallows art pack: a name from files/art (gallows, snowman, ship) or a path to a JSON file")
+	flags.StringVar(&values.theme, "theme", "default",
+		"Color theme of the menu (default, contrast, none), off with NO_COLOR or without a terminal")
+}
+
+func (values *flagValues) gameConfig(command Command, args []string) (*GameConfig, error) {
+	config := &GameConfig{
+		Command:    command,
+		Args:       args,
+		Difficulty: domain.Difficulty(randomAsEmpty(values.difficulty)),
+		Category:   domain.Category(randomAsEmpty(values.category)),
+		Strict:     values.strict,
+		Language:   strings.TrimSpace(values.lang),
+		Art:        values.art,
+		WordPack:   values.words,
+		StatsPath:  values.statsFile,
+		Log:        values.logOptions(),
+	}
+
+	if command != CommandPlay && command != CommandServe {
+		return config, nil
+	}
+
+	invalid := &invalidOptions{strict: values.strict}
+
+	// The accessible mode describes the game line by line, so it turns the full-screen mode off.
+	config.Fullscreen = values.fullscreen && !values.accessible
+	config.Renderer = values.rendererOption()
+	config.Theme = values.theme
+	config.Attempts = nonNegativeOption(invalid, "attempts", values.attempts, "by difficulty")
+	config.HintPolicy = values.hintPolicyOption(invalid)
+	config.TurnTime = nonNegativeOption(invalid, "turn-time", values.turnTime, "no limit")
+	config.GameTime = nonNegativeOption(invalid, "game-time", values.gameTime, "no limit")
+	config.Blitz = values.blitz
+	config.Filter = values.filterOption(invalid)
+	config.Selection = values.selectionOption(invalid)
+	config.Weights = domain.SelectionWeights{
+		Difficulties: weightsOption[domain.Difficulty](invalid, "difficulty-weights", values.diffWeights),
+		Categories:   weightsOption[domain.Category](invalid, "category-weights", values.ctgWeights),
+	}
+	config.Network = values.network.options(command)
+
+	return config, errors.Join(invalid.errs...)
+}
 
-			return "", "", fmt.Errorf("getting random difficulty: %w", err)
+// invalidOptions collects the options set to values the game can not use. With --strict they fail the command,
+// otherwise they are logged and replaced with the defaults.
+type invalidOptions struct {
+	strict bool
+	errs   []error
+}
+
+// report records the error of the invalid value in the strict mode and logs the message with attrs otherwise.
+func (invalid *invalidOptions) report(err *domain.UnknownValueError, message string, attrs ...any) {
+	if invalid.strict {
+		invalid.errs = append(invalid.errs, err)
+		return
+	}
+
+	slog.Info(message, attrs...)
+}
+
+// randomAsEmpty returns the value in lower case, empty when it asks for a random one.
+func randomAsEmpty(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == domain.RandomValue {
+		return ""
+	}
+
+	return value
+}
+
+// rendererOption returns the name of the renderer; the accessible mode has a renderer of its own.
+func (values *flagValues) rendererOption() string {
+	if values.accessible {
+		return "accessible"
+	}
+
+	return values.renderer
+}
+
+// nonNegativeOption returns the value of the numeric option, or zero, which means defaultValue, when it is negative.
+func nonNegativeOption[T int | time.Duration](invalid *invalidOptions, name string, value T, defaultValue string) T {
+	if value >= 0 {
+		return value
+	}
+
+	invalid.report(
+		&domain.UnknownValueError{Message: fmt.Sprintf("%s can not be negative, got %v", name, value)},
+		fmt.Sprintf("%s can not be negative, so the default value is set - %s", strings.ToUpper(name[:1])+name[1:], defaultValue),
+		slog.Any(name, value),
+	)
+
+	return 0
+}
+
+func (values *flagValues) hintPolicyOption(invalid *invalidOptions) domain.HintPolicy {
+	policy, err := domain.ParseHintPolicy(values.hint)
+	if err != nil {
+		// A known mode with a wrong argument, e.g. after:0, is reported as is, an unknown one with suggestions.
+		optionErr := &domain.UnknownValueError{Message: err.Error()}
+		if mode, _, _ := strings.Cut(strings.ToLower(values.hint), ":"); !slices.Contains(hintModes, mode) {
+			optionErr = domain.NewOptionError("hint policy", mode, hintModes)
 		}
 
-		fmt.Printf("Difficulty not found in list of available values or flags is missing, so the default value is set - random \n")
+		invalid.report(
+			optionErr,
+			"Hint policy not found in list of available values, so the default value is set - half",
+			slog.String("hint", values.hint),
+			slog.String("error", err.Error()),
+		)
 
-		inputDifficulty = randomDifficulty
+		return domain.DefaultHintPolicy
 	}
 
-	if !slices.Contains(dwp.AllCategories, inputCategory) {
-		slog.Info(
-			"Category not found in list of available values or flags is missing, so the default value is set - random",
-			slog.String("category", string(inputCategory)),
+	return policy
+}
+
+// hintModes are the hint policies suggested for a wrong --hint.
+var hintModes = []string{
+	string(domain.HintModeHalf),
+	string(domain.HintModeNever),
+	string(domain.HintModeOnDemand),
+	string(domain.HintModeAfter),
+	string(domain.HintModeReveal),
+}
+
+func (values *flagValues) filterOption(invalid *invalidOptions) domain.WordFilter {
+	return domain.WordFilter{
+		MinLength:      nonNegativeOption(invalid, "min-length", values.minLength, "0"),
+		MaxLength:      nonNegativeOption(invalid, "max-length", values.maxLength, "0"),
+		ExcludeLetters: strings.ToLower(strings.TrimSpace(values.exclude)),
+		Tag:            strings.TrimSpace(values.tag),
+		NoSpaces:       !values.spaces,
+	}
+}
+
+func (values *flagValues) selectionOption(invalid *invalidOptions) domain.SelectionStrategy {
+	strategy := domain.SelectionStrategy(strings.ToLower(values.selection))
+
+	switch strategy {
+	case domain.SelectionUniformCategories, domain.SelectionUniformWords, domain.SelectionWeighted:
+		return strategy
+	default:
+		invalid.report(
+			domain.NewOptionError("selection", values.selection, []string{
+				string(domain.SelectionUniformCategories),
+				string(domain.SelectionUniformWords),
+				string(domain.SelectionWeighted),
+			}),
+			"Selection not found in list of available values, so the default value is set - categories",
+			slog.String("selection", values.selection),
 		)
 
-		randomCategory, err := dwp.GetRandomCategoryFromDifficulty(inputDifficulty)
-		if err != nil {
-			slog.Error(
-				"getting random category",
-				slog.String("error", err.Error()),
+		return domain.SelectionUniformCategories
+	}
+}
+
+// weightsOption returns the weights of a list like hard=2,easy=0, nil when it has no valid entries.
+func weightsOption[K ~string](invalid *invalidOptions, name, value string) map[K]int {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+
+	weights := make(map[K]int)
+
+	for _, entry := range strings.Split(value, ",") {
+		key, weightText, found := strings.Cut(entry, "=")
+		weight, err := strconv.Atoi(strings.TrimSpace(weightText))
+
+		if !found || err != nil || weight < 0 || strings.TrimSpace(key) == "" {
+			invalid.report(
+				&domain.UnknownValueError{Message: fmt.Sprintf("%s entry %q is not a name and a non-negative number", name, entry)},
+				"Weight is not a name and a non-negative number, so the entry is ignored",
+				slog.String(name, entry),
 			)
 
-			return "", "", fmt.Errorf("getting random category: %w", err)
+			continue
 		}
 
-		fmt.Printf("Category not found in list of available values or flags is missing, so the default value is set - random \n")
+		weights[K(strings.ToLower(strings.TrimSpace(key)))] = weight
+	}
 
-		inputCategory = randomCategory
+	if len(weights) == 0 {
+		return nil
 	}
 
-	return inputCategory, inputDifficulty, nil
+	return weights
 }
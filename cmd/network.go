@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"flag"
+	"strings"
+)
+
+// defaultServeAddress is served by the serve command when neither --host nor --serve is given.
+const defaultServeAddress = ":8080"
+
+type NetworkOptions struct {
+	HostAddress  string
+	JoinAddress  string
+	ServeAddress string
+	RoomMode     string
+}
+
+type networkValues struct {
+	host     string
+	join     string
+	serve    string
+	roomMode string
+}
+
+func addNetworkFlags(flags *flag.FlagSet, values *networkValues) {
+	flags.StringVar(&values.host, "host", "", "Address to host a multiplayer room on over TCP (e.g. :7777)")
+	flags.StringVar(&values.join, "join", "", "Address of a multiplayer room to join over TCP (e.g. 192.168.0.10:7777)")
+	flags.StringVar(&values.serve, "serve", "", "Address to serve the live game page and WebSocket endpoint on (e.g. :8080)")
+	flags.StringVar(&values.roomMode, "room-mode", "turns", "Multiplayer room mode (turns, race)")
+}
+
+// options returns the network options of the game; the serve command always hosts a room.
+func (values *networkValues) options(command Command) NetworkOptions {
+	options := NetworkOptions{
+		HostAddress:  values.host,
+		JoinAddress:  values.join,
+		ServeAddress: values.serve,
+		RoomMode:     strings.ToLower(values.roomMode),
+	}
+
+	if command == CommandServe {
+		options.JoinAddress = ""
+
+		if options.HostAddress == "" && options.ServeAddress == "" {
+			options.ServeAddress = defaultServeAddress
+		}
+	}
+
+	return options
+}
@@ -1,21 +1,46 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/application"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
 )
 
 func main() {
-	logger, err := infrastructure.InitLogger()
+	os.Exit(run())
+}
+
+// run runs the program and returns its exit code, so the logger is closed before the program exits.
+func run() int {
+	flushLogs := infrastructure.BufferLogs()
+
+	config, settingsErr := cmd.Parse(os.Args[1:])
+	if errors.Is(settingsErr, flag.ErrHelp) {
+		return cmd.ExitSuccess
+	}
+
+	if config == nil {
+		// The flag package has already printed the error together with the usage of the command.
+		return cmd.ExitUsage
+	}
+
+	logger, err := infrastructure.InitLogger(config.Log.FilePath, config.Log.Level)
 	if err != nil {
 		fmt.Println("failed to initialize logger:", err)
-		return
+		return cmd.ExitFailure
 	}
 
 	slog.SetDefault(logger.Logger)
+	flushLogs(logger.Logger)
 
 	defer func() {
 		if err := infrastructure.CloseLogger(logger); err != nil {
@@ -23,5 +48,11 @@ func main() {
 		}
 	}()
 
-	application.ManageGame()
+	// SIGINT and SIGTERM stop the game gracefully, a second signal kills the program as usual.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	context.AfterFunc(ctx, stop)
+
+	return application.RunCommand(ctx, config, settingsErr, os.Stdout)
 }
@@ -3,8 +3,10 @@ module github.com/es-debug/backend-academy-2024-go-template
 go 1.22.6
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/stretchr/testify v1.3.0
 	github.com/xeipuuv/gojsonschema v1.2.0
+	golang.org/x/term v0.25.0
 	golang.org/x/text v0.18.0
 )
 
@@ -13,4 +15,5 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
 	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 )
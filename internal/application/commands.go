@@ -0,0 +1,230 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
+	"github.com/es-debug/backend-academy-2024-go-template/pkg/apperrors"
+)
+
+// RunCommand runs the command of the config and returns the exit code of the program. The list, validate and stats
+// commands print to out. settingsErr is the error of applying the environment and the config file, if any.
+// ctx stops the game of the play and serve commands.
+func RunCommand(ctx context.Context, config *cmd.GameConfig, settingsErr error, out io.Writer) int {
+	infrastructure.SetLanguage(infrastructure.DetectLanguage(config.Language))
+
+	// With --strict, options set to values the game can not use fail like unknown categories and difficulties.
+	if unknownValueErr := new(domain.UnknownValueError); errors.As(settingsErr, &unknownValueErr) {
+		return initError(settingsErr)
+	}
+
+	if settingsErr != nil {
+		slog.Error("applying settings", slog.String("error", settingsErr.Error()))
+		fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageConfigError), settingsErr)
+
+		return cmd.ExitUsage
+	}
+
+	command, args := config.Command, config.Args
+
+	switch command {
+	case cmd.CommandPlay, cmd.CommandServe:
+		return ManageGame(ctx, config)
+	case cmd.CommandList:
+		topic, known := cmd.ParseListTopic(args)
+		if !known {
+			cmd.PrintUsage(command)
+			return cmd.ExitUsage
+		}
+
+		return ListWordPack(out, config, topic)
+	case cmd.CommandValidate:
+		return ValidateWordPacks(out, config, args)
+	case cmd.CommandStats:
+		return ShowStats(out, config.StatsPath)
+	case cmd.CommandHelp:
+		if !cmd.PrintHelp(args) {
+			return cmd.ExitUsage
+		}
+
+		return cmd.ExitSuccess
+	default:
+		cmd.PrintUnknownCommand(command)
+		return cmd.ExitUsage
+	}
+}
+
+// ListWordPack prints the categories or difficulties of the word pack with the number of words in each to out.
+func ListWordPack(out io.Writer, config *cmd.GameConfig, topic cmd.ListTopic) int {
+	provider, err := LoadWordProvider(config.WordPack)
+	if err != nil {
+		fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageWordPackError), apperrors.UnwrapError(err))
+		return cmd.ExitFailure
+	}
+
+	if topic == cmd.ListDifficulties {
+		for _, diff := range sorted(provider.AllDifficulties) {
+			attempts, exists := provider.GetAttempts(diff)
+			if !exists {
+				attempts = domain.AttemptsForDifficulty(diff)
+			}
+
+			fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageListDifficulty, diff, attempts, provider.CountWords(diff, "")))
+		}
+
+		return cmd.ExitSuccess
+	}
+
+	difficulties := provider.AllDifficulties
+
+	if diff := config.Difficulty; diff != "" {
+		if !slices.Contains(provider.AllDifficulties, diff) {
+			suggestions := domain.Suggest(diff, provider.AllDifficulties)
+			fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageNoDifficulty, diff, didYouMean(suggestions)))
+
+			return cmd.ExitBadInput
+		}
+
+		difficulties = []domain.Difficulty{diff}
+	}
+
+	for _, ctg := range sorted(provider.AllCategories) {
+		count := 0
+
+		for _, diff := range difficulties {
+			count += provider.CountWords(diff, ctg)
+		}
+
+		if count > 0 {
+			fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageListCategory, ctg, count))
+		}
+	}
+
+	return cmd.ExitSuccess
+}
+
+// ValidateWordPacks checks the word packs at paths, or the default pack when there are none,
+// and reports the result for each of them to out.
+func ValidateWordPacks(out io.Writer, config *cmd.GameConfig, paths []string) int {
+	if len(paths) == 0 {
+		defaultPath, err := WordPackPath(config.WordPack)
+		if err != nil {
+			fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageWordPackError), apperrors.UnwrapError(err))
+			return cmd.ExitFailure
+		}
+
+		paths = []string{defaultPath}
+	}
+
+	exitCode := cmd.ExitSuccess
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			slog.Error("getting absolute path to word pack file", slog.String("error", err.Error()))
+			fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageInvalidPack, path), apperrors.UnwrapError(err))
+
+			exitCode = cmd.ExitFailure
+
+			continue
+		}
+
+		provider, err := infrastructure.CreateProviderFromJSONFile(absPath)
+		if err != nil {
+			fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageInvalidPack, path), apperrors.UnwrapError(err))
+
+			exitCode = cmd.ExitFailure
+
+			continue
+		}
+
+		fmt.Fprintln(out, infrastructure.Localize(
+			infrastructure.MessageValidPack,
+			path,
+			len(provider.AllDifficulties),
+			len(provider.AllCategories),
+			countAllWords(provider),
+		))
+	}
+
+	return exitCode
+}
+
+// ShowStats prints the statistics of the games played in the terminal, recorded in the file at path
+// or in the default statistics file when path is empty, to out.
+func ShowStats(out io.Writer, path string) int {
+	path, err := statsPath(path)
+	if err != nil {
+		fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsError), apperrors.UnwrapError(err))
+		return cmd.ExitFailure
+	}
+
+	records, err := infrastructure.LoadGameRecords(path)
+	if err != nil {
+		fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsError), apperrors.UnwrapError(err))
+		return cmd.ExitFailure
+	}
+
+	stats := domain.SummarizeGames(records)
+	if stats.Played == 0 {
+		fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageNoStats))
+		return cmd.ExitSuccess
+	}
+
+	fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsPlayed, stats.Played))
+	fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsWon, stats.Won, stats.WinRate()))
+	fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsLost, stats.Lost()))
+	fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsStreak, stats.CurrentStreak, stats.BestStreak))
+
+	difficulties := make([]domain.Difficulty, 0, len(stats.ByDifficulty))
+	for diff := range stats.ByDifficulty {
+		difficulties = append(difficulties, diff)
+	}
+
+	for _, diff := range sorted(difficulties) {
+		diffStats := stats.ByDifficulty[diff]
+		fmt.Fprintln(out, infrastructure.Localize(infrastructure.MessageStatsDifficulty, diff, diffStats.Played, diffStats.Won))
+	}
+
+	return cmd.ExitSuccess
+}
+
+// statsPath returns the absolute path of the statistics file at path or, when it is empty, of var/stats.jsonl.
+func statsPath(path string) (string, error) {
+	if path == "" {
+		path = filepath.Join("..", "..", "var", "stats.jsonl")
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		slog.Error("getting absolute path to statistics file", slog.String("error", err.Error()))
+		return "", fmt.Errorf("getting absolute path: %w", err)
+	}
+
+	return absPath, nil
+}
+
+func countAllWords(provider *domain.DefaultWordProvider) int {
+	count := 0
+
+	for _, diff := range provider.AllDifficulties {
+		count += provider.CountWords(diff, "")
+	}
+
+	return count
+}
+
+func sorted[T ~string](values []T) []T {
+	values = slices.Clone(values)
+	slices.Sort(values)
+
+	return values
+}
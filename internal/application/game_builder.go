@@ -4,42 +4,199 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"slices"
+	"strings"
 
-	"github.com/es-debug/backend-academy-2024-go-template/cmd"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
 )
 
-func InitializeGame() (*domain.Game, error) {
-	absPath, err := filepath.Abs(filepath.Join("..", "..", "files", "words.json"))
+// LoadArtPack returns the frames of the gallows art pack of the name or path, none for an empty name,
+// which draws the built-in gallows.
+func LoadArtPack(name string) ([]string, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	absPath, err := filepath.Abs(infrastructure.FindArtPack(filepath.Join("..", "..", "files"), name))
 	if err != nil {
-		slog.Error("getting absolute path to words.json file", slog.String("error", err.Error()))
+		slog.Error("getting absolute path to art pack file", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("getting absolute path: %w", err)
 	}
 
-	provider, err := infrastructure.CreateProviderFromJSONFile(absPath)
+	frames, err := infrastructure.LoadArtPack(absPath)
+	if err != nil {
+		slog.Error("loading art pack", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("loading art pack: %w", err)
+	}
+
+	return frames, nil
+}
+
+// LoadWordProvider loads the word pack at path or, when it is empty, the pack in the interface language.
+func LoadWordProvider(path string) (*domain.DefaultWordProvider, error) {
+	wordPackPath, err := WordPackPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := infrastructure.CreateProviderFromJSONFile(wordPackPath)
 	if err != nil {
 		slog.Error("creating provider from JSON file", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("creating provider from JSON file: %w", err)
 	}
 
-	ctg, diff, err := cmd.ParseFlag(provider)
+	return provider, nil
+}
+
+// WordPackPath returns the absolute path of the word pack at path or, when it is empty, of the pack in the interface language.
+func WordPackPath(wordPackPath string) (string, error) {
+	if wordPackPath == "" {
+		defaultPath, err := infrastructure.FindWordPack(filepath.Join("..", "..", "files"), infrastructure.GetLanguage())
+		if err != nil {
+			slog.Error("finding word pack", slog.String("error", err.Error()))
+			return "", fmt.Errorf("finding word pack: %w", err)
+		}
+
+		wordPackPath = defaultPath
+	}
+
+	absPath, err := filepath.Abs(wordPackPath)
 	if err != nil {
-		slog.Error("parsing flags", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("parsing flags: %w", err)
+		slog.Error("getting absolute path to word pack file", slog.String("error", err.Error()))
+		return "", fmt.Errorf("getting absolute path: %w", err)
 	}
 
-	wordAndHint, err := provider.GetRandomWordAndHintFromCategory(ctg, diff)
+	return absPath, nil
+}
+
+// ChooseCategoryAndDifficulty returns the category and difficulty of the game, picking empty and, unless strict is set,
+// unknown ones at random. When strict is set, unknown values fail with domain.UnknownValueError, otherwise
+// they are reported on stdout.
+func ChooseCategoryAndDifficulty(
+	dwp *domain.DefaultWordProvider,
+	ctg domain.Category,
+	diff domain.Difficulty,
+	strict bool,
+) (domain.Category, domain.Difficulty, error) {
+	return chooseCategoryAndDifficulty(dwp, ctg, diff, strict, func(message string) {
+		fmt.Println(message)
+	}, slog.Default())
+}
+
+// chooseCategoryAndDifficulty is ChooseCategoryAndDifficulty reporting unknown values with notify and logging with logger.
+func chooseCategoryAndDifficulty(
+	dwp *domain.DefaultWordProvider,
+	ctg domain.Category,
+	diff domain.Difficulty,
+	strict bool,
+	notify func(message string),
+	logger *slog.Logger,
+) (domain.Category, domain.Difficulty, error) {
+	diff, err := checkValue(diff, dwp.AllDifficulties, strict, "difficulty", infrastructure.MessageRandomDifficulty, notify, logger)
 	if err != nil {
-		slog.Error("getting random word and hint", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("getting random word and hint: %w", err)
+		return "", "", err
 	}
 
-	game, err := domain.NewGame(wordAndHint, ctg, diff)
+	ctg, err = checkValue(ctg, dwp.AllCategories, strict, "category", infrastructure.MessageRandomCategory, notify, logger)
 	if err != nil {
-		slog.Error("creating new game", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("creating new game: %w", err)
+		return "", "", err
+	}
+
+	if diff == "" {
+		randomDifficulty, err := dwp.GetRandomDifficulty()
+		if err != nil {
+			logger.Error(
+				"getting random difficulty",
+				slog.String("error", err.Error()),
+			)
+
+			return "", "", fmt.Errorf("getting random difficulty: %w", err)
+		}
+
+		diff = randomDifficulty
+	}
+
+	if ctg == "" {
+		randomCategory, err := dwp.GetRandomCategoryFromDifficulty(diff)
+		if err != nil {
+			logger.Error(
+				"getting random category",
+				slog.String("error", err.Error()),
+			)
+
+			return "", "", fmt.Errorf("getting random category: %w", err)
+		}
+
+		ctg = randomCategory
+	}
+
+	return ctg, diff, nil
+}
+
+// checkValue returns value when the word pack has it and an empty value when it should be picked at random:
+// when it is empty, or when it is unknown and strict is not set. Unknown values are reported to the player with notify
+// together with the closest available ones, and logged with logger.
+func checkValue[T ~string](
+	value T,
+	available []T,
+	strict bool,
+	name string,
+	randomMessage infrastructure.MessageKey,
+	notify func(message string),
+	logger *slog.Logger,
+) (T, error) {
+	if value == "" || slices.Contains(available, value) {
+		return value, nil
+	}
+
+	suggestions := domain.Suggest(value, available)
+
+	if strict {
+		return "", newUnknownValueError(name, value, available, suggestions)
+	}
+
+	logger.Info(
+		strings.ToUpper(name[:1])+name[1:]+" not found in list of available values, so the default value is set - random",
+		slog.String(name, string(value)),
+	)
+
+	notify(infrastructure.Localize(randomMessage, value, didYouMean(suggestions)))
+
+	return "", nil
+}
+
+func newUnknownValueError[T ~string](name string, value T, available, suggestions []T) *domain.UnknownValueError {
+	message := fmt.Sprintf("%s '%s' not found in the word pack", name, value)
+
+	if len(suggestions) > 0 {
+		message += fmt.Sprintf(", did you mean %s?", joinValues(suggestions))
+	} else {
+		message += fmt.Sprintf(", available values: %s, %s", joinValues(sorted(available)), domain.RandomValue)
+	}
+
+	err := &domain.UnknownValueError{Message: message}
+	for _, suggestion := range suggestions {
+		err.Suggestions = append(err.Suggestions, string(suggestion))
+	}
+
+	return err
+}
+
+// didYouMean returns the localized suggestion of the values, empty when there are none.
+func didYouMean[T ~string](suggestions []T) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	return infrastructure.Localize(infrastructure.MessageDidYouMean, joinValues(suggestions))
+}
+
+func joinValues[T ~string](values []T) string {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, string(value))
 	}
 
-	return game, nil
+	return strings.Join(texts, ", ")
 }
@@ -1,49 +1,248 @@
 package application
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
 	"github.com/es-debug/backend-academy-2024-go-template/pkg/apperrors"
 )
 
-func ManageGame() {
-	game, err := InitializeGame()
+// ManageGame plays a game in the terminal or hosts it as a room and returns the exit code of the program.
+// When ctx is done, e.g. on SIGINT, the game is stopped with the word revealed and cmd.ExitInterrupted is returned.
+func ManageGame(ctx context.Context, config *cmd.GameConfig) int {
+	if err := checkDisplayOptions(config); err != nil {
+		return initError(err)
+	}
+
+	networkOptions := config.Network
+
+	if networkOptions.JoinAddress != "" {
+		return joinRoom(ctx, networkOptions.JoinAddress)
+	}
+
+	art, err := LoadArtPack(config.Art)
 	if err != nil {
-		slog.Error("initializing game", slog.String("error", err.Error()))
-		fmt.Println("Error while initializing game. \nError: ", apperrors.UnwrapError(err))
+		fmt.Println(infrastructure.Localize(infrastructure.MessageArtError), apperrors.UnwrapError(err))
+		return cmd.ExitFailure
+	}
 
-		return
+	options := infrastructure.RenderOptions{
+		Language: infrastructure.GetLanguage(),
+		Theme:    infrastructure.DetectTheme(config.Theme),
+		Art:      art,
 	}
 
-	slog.Info("Game initialized", slog.String("word", game.GetWordAndHint().Word))
-	RunGameLoop(game)
-}
+	hosting := networkOptions.HostAddress != "" || networkOptions.ServeAddress != ""
 
-func RunGameLoop(game *domain.Game) {
-	for game.GetAttempts() <= game.GetMaxAttempts() {
-		infrastructure.PrintGameMenu(game)
+	// A hosted room is played by the clients, so the terminal only shows the messages of the host.
+	renderer := infrastructure.NewRenderer(config.Renderer, options)
+	ui := infrastructure.NewGameUI(config.Fullscreen && !hosting, renderer, options, os.Stdin, os.Stdout, slog.Default())
 
-		input, err := infrastructure.GetLetterFromUser()
-		if err != nil {
-			slog.Error("getting letter from user", slog.String("error", err.Error()))
-			fmt.Println(err)
+	defer ui.Close()
+
+	service, err := newGameService(config, ui)
+	if err != nil {
+		return initError(err)
+	}
 
-			return
+	settings := gameSettings(config, hosting)
+
+	if config.Blitz && !hosting {
+		return playBlitz(ctx, service, settings)
+	}
+
+	game, err := service.NewGame(settings)
+	if err != nil {
+		return initError(err)
+	}
+
+	if hosting {
+		err := HostRoom(ctx, NewRoom(game, RoomMode(networkOptions.RoomMode)), networkOptions)
+
+		if ctx.Err() != nil {
+			ui.ShowMessage(infrastructure.AbandonedMessage(game))
+			return cmd.ExitInterrupted
 		}
 
-		message := game.LetterGuessed(input)
-		fmt.Println(message)
+		if err != nil {
+			return cmd.ExitFailure
+		}
 
-		if gameIsOver, message := game.GameIsOver(); gameIsOver {
-			infrastructure.PrintGameMenu(game) // Print the final state of the game
-			fmt.Println(message)
+		return cmd.ExitSuccess
+	}
 
-			slog.Info("Game is over", slog.String("message to user", message))
+	if err := service.Play(ctx, game); err != nil {
+		slog.Error("playing game", slog.String("error", err.Error()))
 
-			return
+		if ctx.Err() != nil || errors.Is(err, infrastructure.ErrInterrupted) {
+			return cmd.ExitInterrupted
 		}
 	}
+
+	return cmd.ExitSuccess
+}
+
+// checkDisplayOptions fails with domain.UnknownValueError on a renderer or theme the game does not have when --strict
+// is set; otherwise they are replaced with the defaults.
+func checkDisplayOptions(config *cmd.GameConfig) error {
+	if !config.Strict {
+		return nil
+	}
+
+	if names := infrastructure.RendererNames(); !containsFold(names, config.Renderer) {
+		return domain.NewOptionError("renderer", config.Renderer, names)
+	}
+
+	if names := infrastructure.ThemeNames(); !containsFold(names, config.Theme) {
+		return domain.NewOptionError("theme", config.Theme, names)
+	}
+
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(candidate string) bool {
+		return strings.EqualFold(candidate, value)
+	})
+}
+
+// playBlitz plays a blitz with the settings and returns the exit code of the program.
+func playBlitz(ctx context.Context, service *GameService, settings GameSettings) int {
+	score, err := service.PlayBlitz(ctx, settings)
+	if err == nil {
+		return cmd.ExitSuccess
+	}
+
+	if ctx.Err() != nil || errors.Is(err, infrastructure.ErrInterrupted) {
+		return cmd.ExitInterrupted
+	}
+
+	if score.Played == 0 {
+		return initError(err)
+	}
+
+	slog.Error("playing blitz", slog.String("error", err.Error()))
+
+	return cmd.ExitSuccess
+}
+
+// initError reports the error of creating the game and returns the exit code of the program.
+func initError(err error) int {
+	slog.Error("initializing game", slog.String("error", err.Error()))
+	fmt.Println(infrastructure.Localize(infrastructure.MessageInitError), apperrors.UnwrapError(err))
+
+	if unknownValueErr := new(domain.UnknownValueError); errors.As(err, &unknownValueErr) {
+		return cmd.ExitBadInput
+	}
+
+	return cmd.ExitFailure
+}
+
+// joinRoom plays in the room at address until it is closed or ctx is done and returns the exit code of the program.
+func joinRoom(ctx context.Context, address string) int {
+	err := infrastructure.JoinRoom(ctx, address, os.Stdin, os.Stdout)
+	if ctx.Err() != nil {
+		return cmd.ExitInterrupted
+	}
+
+	if err != nil {
+		fmt.Println(infrastructure.Localize(infrastructure.MessageJoinError), apperrors.UnwrapError(err))
+		return cmd.ExitFailure
+	}
+
+	return cmd.ExitSuccess
+}
+
+// gameSettings returns the settings of the config; rooms do not keep time, so hosted games have no time limits
+// and are played one word at a time.
+func gameSettings(config *cmd.GameConfig, hosting bool) GameSettings {
+	settings := SettingsFromConfig(config)
+
+	if hosting && config.Blitz {
+		slog.Info("Blitz is not supported in rooms, so the default value is set - a single word")
+	}
+
+	if hosting && (settings.TurnTime > 0 || settings.GameTime > 0) {
+		slog.Info("Time limits are not supported in rooms, so the default value is set - no limit")
+
+		settings.TurnTime, settings.GameTime = 0, 0
+	}
+
+	return settings
+}
+
+// newGameService creates the service playing through ui with the word pack of the config, recording the games
+// in the statistics file.
+func newGameService(config *cmd.GameConfig, ui infrastructure.GameUI) (*GameService, error) {
+	provider, err := LoadWordProvider(config.WordPack)
+	if err != nil {
+		return nil, err
+	}
+
+	provider.Filter = config.Filter
+	provider.Strategy = config.Selection
+	provider.Weights = config.Weights.Or(provider.Weights)
+
+	service := NewGameService(provider, ui, domain.SystemClock{}, slog.Default())
+
+	if path, err := statsPath(config.StatsPath); err == nil {
+		service.SetRecorder(infrastructure.StatsFile{Path: path})
+	}
+
+	return service, nil
+}
+
+// HostRoom shares the room over TCP, over HTTP with WebSocket or over both at once until the game is over
+// or ctx is done, which closes the room. When a server fails, e.g. to listen on its address, the room is closed
+// for the other one too and the errors are returned.
+func HostRoom(ctx context.Context, room *Room, networkOptions cmd.NetworkOptions) error {
+	stop := context.AfterFunc(ctx, room.Close)
+	defer stop()
+
+	var (
+		servers sync.WaitGroup
+		errs    = make(chan error, 2)
+	)
+
+	serve := func(run func() error, message infrastructure.MessageKey) {
+		servers.Add(1)
+
+		go func() {
+			defer servers.Done()
+
+			if err := run(); err != nil {
+				fmt.Println(infrastructure.Localize(message), apperrors.UnwrapError(err))
+				room.Close()
+
+				errs <- err
+			}
+		}()
+	}
+
+	if networkOptions.HostAddress != "" {
+		serve(func() error { return infrastructure.HostRoom(networkOptions.HostAddress, room) }, infrastructure.MessageHostError)
+	}
+
+	if networkOptions.ServeAddress != "" {
+		serve(func() error { return infrastructure.ServeRoom(networkOptions.ServeAddress, room) }, infrastructure.MessageServeError)
+	}
+
+	servers.Wait()
+	close(errs)
+
+	var joined []error
+	for err := range errs {
+		joined = append(joined, err)
+	}
+
+	return errors.Join(joined...)
 }
@@ -0,0 +1,345 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
+)
+
+// GameRecorder keeps the records of finished games, e.g. infrastructure.StatsFile.
+type GameRecorder interface {
+	RecordGame(record domain.GameRecord) error
+}
+
+// GameSettings are the choices the game is created with.
+type GameSettings struct {
+	// Category and Difficulty are empty when they should be picked at random.
+	Category   domain.Category
+	Difficulty domain.Difficulty
+	// Strict fails on a difficulty or category missing from the word pack instead of picking a random one.
+	Strict bool
+	// Attempts is zero when they depend on the difficulty.
+	Attempts   int
+	HintPolicy domain.HintPolicy
+	// TurnTime and GameTime limit the time of a guess and of the whole game, zero when there is no limit.
+	TurnTime time.Duration
+	GameTime time.Duration
+	// ExcludeWords are not picked, e.g. the words already played in a blitz.
+	ExcludeWords []string
+}
+
+// SettingsFromConfig returns the settings of the game chosen on the command line.
+func SettingsFromConfig(config *cmd.GameConfig) GameSettings {
+	return GameSettings{
+		Category:   config.Category,
+		Difficulty: config.Difficulty,
+		Strict:     config.Strict,
+		Attempts:   config.Attempts,
+		HintPolicy: config.HintPolicy,
+		TurnTime:   config.TurnTime,
+		GameTime:   config.GameTime,
+	}
+}
+
+// GameService creates and plays games with the dependencies it is constructed with,
+// so the game can be embedded into other programs and played from tests.
+type GameService struct {
+	provider *domain.DefaultWordProvider
+	ui       infrastructure.GameUI
+	clock    domain.Clock
+	logger   *slog.Logger
+	recorder GameRecorder
+}
+
+// NewGameService returns the service taking words from provider and playing them with the player through ui.
+// Finished games are not recorded until a recorder is set.
+func NewGameService(
+	provider *domain.DefaultWordProvider,
+	ui infrastructure.GameUI,
+	clock domain.Clock,
+	logger *slog.Logger,
+) *GameService {
+	return &GameService{
+		provider: provider,
+		ui:       ui,
+		clock:    clock,
+		logger:   logger,
+	}
+}
+
+func (s *GameService) SetRecorder(recorder GameRecorder) {
+	s.recorder = recorder
+}
+
+// NewGame picks a word for the settings and creates the game; unknown values picked at random are reported
+// to the player through the UI. The game clock starts right away.
+func (s *GameService) NewGame(settings GameSettings) (*domain.Game, error) {
+	provider := s.provider
+	if len(settings.ExcludeWords) > 0 {
+		provider = excludingWords(s.provider, settings.ExcludeWords)
+	}
+
+	ctg, diff, err := chooseCategoryAndDifficulty(
+		provider,
+		settings.Category,
+		settings.Difficulty,
+		settings.Strict,
+		s.ui.ShowMessage,
+		s.logger,
+	)
+	if err != nil {
+		s.logger.Error("choosing category and difficulty", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("choosing category and difficulty: %w", err)
+	}
+
+	wordAndHint, err := provider.GetRandomWordAndHintFromCategory(ctg, diff)
+	if err != nil {
+		s.logger.Error("getting random word and hint", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("getting random word and hint: %w", err)
+	}
+
+	game, err := domain.NewGame(wordAndHint, ctg, diff)
+	if err != nil {
+		s.logger.Error("creating new game", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("creating new game: %w", err)
+	}
+
+	if settings.Attempts > 0 {
+		game.SetMaxAttempts(settings.Attempts)
+	} else if attempts, exists := provider.GetAttempts(diff); exists {
+		game.SetMaxAttempts(attempts)
+	}
+
+	if game.GetLanguage() == "" {
+		game.SetLanguage(provider.Language)
+	}
+
+	game.SetHintPolicy(settings.HintPolicy)
+	game.SetClock(s.clock)
+	game.SetTurnTimeout(settings.TurnTime)
+	game.SetTimeLimit(settings.GameTime)
+	game.Subscribe(s.logGameEvent)
+
+	s.logger.Info("Game initialized", slog.String("word", wordAndHint.Word))
+
+	return game, nil
+}
+
+// excludingWords returns a copy of the provider that does not pick the words, so the provider of the service,
+// which may be shared with other games, stays unchanged.
+func excludingWords(provider *domain.DefaultWordProvider, words []string) *domain.DefaultWordProvider {
+	narrowed := *provider
+	narrowed.Filter.ExcludeWords = append(slices.Clip(provider.Filter.ExcludeWords), words...)
+
+	return &narrowed
+}
+
+// Play asks the player for letters and commands until the game is over and records the finished game.
+// A guess not made within the turn timeout of the game costs an attempt, and the game is lost once its time limit
+// has passed. When ctx is done or the player interrupts the game, it reveals the word, records the game as abandoned
+// and returns the error of ctx or infrastructure.ErrInterrupted. Other errors of reading the input leave
+// the game unfinished.
+func (s *GameService) Play(ctx context.Context, game *domain.Game) error {
+	for game.GetAttempts() <= game.GetMaxAttempts() {
+		if game.ExpireIfTimeIsUp() {
+			s.finish(game)
+			return nil
+		}
+
+		s.ui.ShowGame(game)
+
+		if game.GetHintPolicy().IsOnDemand() {
+			s.ui.ShowMessage(infrastructure.Localize(infrastructure.MessageHintCommand))
+		}
+
+		input, err := s.readInput(ctx, game)
+		if ctx.Err() != nil || errors.Is(err, infrastructure.ErrInterrupted) {
+			s.abandon(game)
+			return fmt.Errorf("playing game: %w", cmp.Or(ctx.Err(), err))
+		}
+
+		var result domain.GuessResult
+
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			if game.ExpireIfTimeIsUp() {
+				s.finish(game)
+				return nil
+			}
+
+			result = game.TurnTimedOut()
+		case err != nil:
+			s.logger.Error("getting input from user", slog.String("error", err.Error()))
+			s.ui.ShowMessage(err.Error())
+
+			return fmt.Errorf("getting input from user: %w", err)
+		case input.Command == infrastructure.CommandHint:
+			result = game.RequestHint()
+		default:
+			result = game.LetterGuessed(input.Letter)
+		}
+
+		s.ui.ShowMessage(infrastructure.GuessMessage(result))
+
+		if gameIsOver, _ := game.GameIsOver(); gameIsOver {
+			s.finish(game)
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// PlayBlitz plays words back to back until one of them is lost, the time limit of the settings passes or the word
+// pack runs out of words, and returns how many words were solved. The attempts and the time left after a solved word
+// carry over to the next one, so settings.Attempts is the pool of attempts of the blitz, by default the attempts
+// of the first word. The words played are excluded from the next ones. Errors are those of NewGame and Play.
+func (s *GameService) PlayBlitz(ctx context.Context, settings GameSettings) (domain.BlitzScore, error) {
+	var score domain.BlitzScore
+
+	for {
+		game, err := s.NewGame(settings)
+		if notFoundErr := new(domain.NotFoundError); score.Played > 0 && errors.As(err, &notFoundErr) {
+			s.ui.ShowMessage(infrastructure.Localize(infrastructure.MessageBlitzNoWords))
+			break
+		}
+
+		if err != nil {
+			return score, err
+		}
+
+		err = s.Play(ctx, game)
+		score.Add(game)
+
+		if err != nil {
+			return score, err
+		}
+
+		if _, result := game.GameIsOver(); result != domain.EventWon {
+			break
+		}
+
+		var next bool
+		if settings, next = nextBlitzSettings(settings, game); !next {
+			break
+		}
+
+		settings.ExcludeWords = append(slices.Clip(settings.ExcludeWords), game.GetWordAndHint().Word)
+
+		s.ui.ShowMessage(infrastructure.Localize(infrastructure.MessageBlitzNext, settings.Attempts))
+	}
+
+	s.logger.Info("Blitz finished", slog.Int("solved", score.Solved), slog.Int("played", score.Played))
+	s.ui.ShowMessage(infrastructure.Localize(infrastructure.MessageBlitzScore, score.Solved, score.Played))
+
+	return score, nil
+}
+
+// nextBlitzSettings returns the settings of the word after the solved game with the attempts and the time left,
+// or false when either of them is used up. Zero attempts would mean the attempts of the difficulty, so they end
+// the blitz too.
+func nextBlitzSettings(settings GameSettings, game *domain.Game) (GameSettings, bool) {
+	settings.Attempts = game.GetMaxAttempts() - game.GetAttempts()
+	if settings.Attempts <= 0 {
+		return settings, false
+	}
+
+	if left, limited := game.GetTimeLeft(); limited {
+		if left <= 0 {
+			return settings, false
+		}
+
+		settings.GameTime = left
+	}
+
+	// Values missing from the word pack were replaced at random and reported with the first word, so the next
+	// words are picked at random without reporting them again.
+	if settings.Category != game.GetCategory() {
+		settings.Category = ""
+	}
+
+	if settings.Difficulty != game.GetDifficulty() {
+		settings.Difficulty = ""
+	}
+
+	return settings, true
+}
+
+// readInput waits for the input of the player until the turn timeout or the time limit of the game,
+// whichever comes first, in which case it returns context.DeadlineExceeded. Both are waited for with the clock
+// of the service, which also measures the time limit.
+func (s *GameService) readInput(ctx context.Context, game *domain.Game) (infrastructure.PlayerInput, error) {
+	timeout := game.GetTurnTimeout()
+	timed := timeout > 0
+
+	if left, limited := game.GetTimeLeft(); limited && (!timed || left < timeout) {
+		timeout, timed = left, true
+	}
+
+	if !timed {
+		return s.ui.ReadInput(ctx)
+	}
+
+	turnCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	deadline := s.clock.After(timeout)
+
+	go func() {
+		select {
+		case <-deadline:
+			cancel(context.DeadlineExceeded)
+		case <-turnCtx.Done():
+		}
+	}()
+
+	input, err := s.ui.ReadInput(turnCtx)
+	if err != nil && ctx.Err() == nil && turnCtx.Err() != nil {
+		return input, context.Cause(turnCtx)
+	}
+
+	return input, err
+}
+
+// finish shows the final state of the game and records it.
+func (s *GameService) finish(game *domain.Game) {
+	s.ui.ShowGame(game)
+	s.ui.ShowMessage(infrastructure.GameOverMessage(game))
+	s.recordGame(game)
+}
+
+// abandon reveals the word of the game stopped before it was over and records it.
+func (s *GameService) abandon(game *domain.Game) {
+	s.logger.Info("Game abandoned", slog.String("word", game.GetWordAndHint().Word))
+	s.ui.ShowMessage(infrastructure.AbandonedMessage(game))
+	s.recordGame(game)
+}
+
+// recordGame adds the game to the statistics; failures are only logged, as the game itself is over.
+func (s *GameService) recordGame(game *domain.Game) {
+	if s.recorder == nil {
+		return
+	}
+
+	if err := s.recorder.RecordGame(domain.NewGameRecord(game, s.clock.Now())); err != nil {
+		s.logger.Error("recording game", slog.String("error", err.Error()))
+	}
+}
+
+func (s *GameService) logGameEvent(event domain.GameEvent) {
+	s.logger.Info(
+		"Game event",
+		slog.String("type", string(event.Type)),
+		slog.String("letter", event.Letter),
+		slog.String("word", event.Word),
+		slog.Int("attempts", event.Attempts),
+	)
+}
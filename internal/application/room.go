@@ -0,0 +1,288 @@
+package application
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
+)
+
+type RoomMode string
+
+const (
+	RoomModeTurns RoomMode = "turns"
+	RoomModeRace  RoomMode = "race"
+)
+
+type roomPlayer struct {
+	id   int
+	name string
+	hits int
+	send func(message string)
+}
+
+// Room owns the authoritative game of a multiplayer session.
+// domain.Game is not safe for concurrent use, so every access to it goes through the room mutex.
+type Room struct {
+	mu       sync.Mutex
+	game     *domain.Game
+	mode     RoomMode
+	players  []*roomPlayer
+	watchers map[int]func(event domain.GameEvent)
+	// guessingPlayer is the name of the player whose guess the game events currently being emitted belong to.
+	guessingPlayer string
+	turn           int
+	nextID         int
+	done           chan struct{}
+}
+
+func NewRoom(game *domain.Game, mode RoomMode) *Room {
+	if mode != RoomModeTurns && mode != RoomModeRace {
+		slog.Info("Room mode not found in list of available values, so the default value is set - turns", slog.String("mode", string(mode)))
+
+		mode = RoomModeTurns
+	}
+
+	room := &Room{
+		game:     game,
+		mode:     mode,
+		watchers: make(map[int]func(event domain.GameEvent)),
+		done:     make(chan struct{}),
+	}
+
+	game.Subscribe(room.publish)
+
+	return room
+}
+
+// publish forwards game events to the watchers; the game emits them while Guess holds the room lock.
+func (room *Room) publish(event domain.GameEvent) {
+	event.Player = room.guessingPlayer
+
+	for _, send := range room.watchers {
+		send(event)
+	}
+}
+
+// Done returns a channel that is closed once the game in the room is over.
+func (room *Room) Done() <-chan struct{} {
+	return room.done
+}
+
+// Watch subscribes send to the events of the room, starting with the current state of the game.
+// The returned function unsubscribes it.
+func (room *Room) Watch(send func(event domain.GameEvent)) (unwatch func()) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	room.nextID++
+	watcherID := room.nextID
+	room.watchers[watcherID] = send
+
+	send(domain.NewGameEvent(domain.EventState, room.game))
+
+	return func() {
+		room.mu.Lock()
+		defer room.mu.Unlock()
+
+		delete(room.watchers, watcherID)
+	}
+}
+
+func (room *Room) Join(name string, send func(message string)) (int, error) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.isOver() {
+		return 0, &domain.RoomError{Message: infrastructure.Localize(infrastructure.MessageGameAlreadyOver)}
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = fmt.Sprintf("player%d", room.nextID+1)
+	}
+
+	room.nextID++
+	player := &roomPlayer{id: room.nextID, name: name, send: send}
+	room.players = append(room.players, player)
+
+	slog.Info("Player joined room", slog.String("name", name), slog.Int("players", len(room.players)))
+
+	room.broadcast(infrastructure.Localize(infrastructure.MessagePlayerJoined, name, room.mode))
+	room.broadcast(room.status())
+
+	return player.id, nil
+}
+
+func (room *Room) Leave(playerID int) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	index := room.playerIndex(playerID)
+	if index == -1 {
+		return
+	}
+
+	player := room.players[index]
+	room.players = append(room.players[:index], room.players[index+1:]...)
+
+	if index < room.turn {
+		room.turn--
+	}
+
+	if room.turn >= len(room.players) {
+		room.turn = 0
+	}
+
+	slog.Info("Player left room", slog.String("name", player.name), slog.Int("players", len(room.players)))
+
+	if !room.isOver() {
+		room.broadcast(infrastructure.Localize(infrastructure.MessagePlayerLeft, player.name))
+		room.broadcast(room.status())
+	}
+}
+
+func (room *Room) Guess(playerID int, letter rune) error {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.isOver() {
+		return &domain.RoomError{Message: infrastructure.Localize(infrastructure.MessageGameAlreadyOver)}
+	}
+
+	index := room.playerIndex(playerID)
+	if index == -1 {
+		return &domain.RoomError{Message: infrastructure.Localize(infrastructure.MessageNotInRoom)}
+	}
+
+	player := room.players[index]
+
+	if room.mode == RoomModeTurns && index != room.turn {
+		return &domain.RoomError{Message: infrastructure.Localize(infrastructure.MessageNotYourTurn, room.players[room.turn].name)}
+	}
+
+	room.guessingPlayer = player.name
+	result := room.game.LetterGuessed(letter)
+
+	switch result.Outcome {
+	case domain.EventAlreadyGuessed:
+		return &domain.RoomError{Message: infrastructure.GuessMessage(result)}
+	case domain.EventHit:
+		player.hits++
+	}
+
+	slog.Info(
+		"Room guess",
+		slog.String("player", player.name),
+		slog.String("letter", string(letter)),
+		slog.String("result", string(result.Outcome)),
+	)
+
+	room.broadcast(infrastructure.Localize(infrastructure.MessagePlayerGuessed, player.name, letter, infrastructure.GuessMessage(result)))
+
+	if gameIsOver, _ := room.game.GameIsOver(); gameIsOver {
+		message := infrastructure.GameOverMessage(room.game)
+
+		room.broadcast(room.status())
+		room.broadcast(message)
+		room.broadcast(room.scoreboard(player))
+
+		slog.Info("Room game is over", slog.String("message to user", message))
+		close(room.done)
+
+		return nil
+	}
+
+	if room.mode == RoomModeTurns {
+		room.turn = (room.turn + 1) % len(room.players)
+	}
+
+	room.broadcast(room.status())
+
+	return nil
+}
+
+// Close stops the game in the room before it is over, revealing the word to the players.
+func (room *Room) Close() {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.isOver() {
+		return
+	}
+
+	slog.Info("Room closed", slog.String("word", room.game.GetWordAndHint().Word))
+
+	room.broadcast(infrastructure.AbandonedMessage(room.game))
+	close(room.done)
+}
+
+func (room *Room) isOver() bool {
+	select {
+	case <-room.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (room *Room) playerIndex(playerID int) int {
+	for i, player := range room.players {
+		if player.id == playerID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (room *Room) broadcast(message string) {
+	for _, player := range room.players {
+		player.send(message)
+	}
+}
+
+func (room *Room) status() string {
+	status := fmt.Sprintf(
+		"%s: %s | %s: %d/%d",
+		infrastructure.Localize(infrastructure.MessageWord),
+		room.game.GetWordWithGuesses(),
+		infrastructure.Localize(infrastructure.MessageAttempts),
+		room.game.GetAttempts(),
+		room.game.GetMaxAttempts(),
+	)
+
+	if misses := room.game.GetMisses(); len(misses) > 0 {
+		status += " | " + infrastructure.Localize(infrastructure.MessageMisses) + ": " + string(misses)
+	}
+
+	if hints := room.game.GetUnlockedHints(); len(hints) > 0 {
+		status += " | " + infrastructure.Localize(infrastructure.MessageHint) + ": " + strings.Join(hints, "; ")
+	}
+
+	if gameIsOver, _ := room.game.GameIsOver(); room.mode == RoomModeTurns && len(room.players) > 0 && !gameIsOver {
+		status += " | " + infrastructure.Localize(infrastructure.MessageTurn) + ": " + room.players[room.turn].name
+	}
+
+	return status
+}
+
+// scoreboard lists correct guesses per player; the player who made the last guess closes the word in race mode.
+func (room *Room) scoreboard(last *roomPlayer) string {
+	var scoreboard strings.Builder
+
+	scoreboard.WriteString(infrastructure.Localize(infrastructure.MessageScoreboard))
+
+	for _, player := range room.players {
+		scoreboard.WriteString("\n" + infrastructure.Localize(infrastructure.MessageScore, player.name, player.hits))
+	}
+
+	if room.game.WordGuessed() {
+		scoreboard.WriteString("\n" + infrastructure.Localize(infrastructure.MessageCompletedBy, last.name))
+	}
+
+	return scoreboard.String()
+}
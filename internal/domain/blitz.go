@@ -0,0 +1,16 @@
+package domain
+
+// BlitzScore tallies a blitz, in which words are played back to back sharing the attempts and the time.
+type BlitzScore struct {
+	Played int
+	Solved int
+}
+
+// Add counts the game, which is solved when it was won.
+func (score *BlitzScore) Add(game *Game) {
+	score.Played++
+
+	if _, result := game.GameIsOver(); result == EventWon {
+		score.Solved++
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Clock tells the current time and waits for it, so the code depending on it can be tested with a fixed one.
+type Clock interface {
+	Now() time.Time
+	// After sends the time on the returned channel once d has passed, like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the clock of the operating system.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -1,3 +1,18 @@
 package domain
 
 type Difficulty string
+
+// AttemptsForDifficulty returns the attempts the player gets on diff: the difficulties every word pack uses
+// have their own, other difficulties get MaxAttempts unless the word pack configures them.
+func AttemptsForDifficulty(diff Difficulty) int {
+	switch diff {
+	case "easy":
+		return 9
+	case "medium":
+		return 7
+	case "hard":
+		return 5
+	default:
+		return MaxAttempts
+	}
+}
@@ -15,3 +15,30 @@ func (e *InvalidLengthError) Error() string {
 func (e *NotFoundError) Error() string {
 	return e.Message
 }
+
+type RoomError struct {
+	Message string
+}
+
+func (e *RoomError) Error() string {
+	return e.Message
+}
+
+type HintPolicyError struct {
+	Message string
+}
+
+func (e *HintPolicyError) Error() string {
+	return e.Message
+}
+
+// UnknownValueError reports a value the game can not use, e.g. a category missing from the word pack
+// or a negative number of attempts, with the closest available values.
+type UnknownValueError struct {
+	Message     string
+	Suggestions []string
+}
+
+func (e *UnknownValueError) Error() string {
+	return e.Message
+}
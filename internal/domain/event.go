@@ -0,0 +1,43 @@
+package domain
+
+type EventType string
+
+const (
+	EventState          EventType = "state"
+	EventAlreadyGuessed EventType = "already_guessed"
+	EventHit            EventType = "hit"
+	EventMiss           EventType = "miss"
+	EventHintUnlocked   EventType = "hint_unlocked"
+	EventLetterRevealed EventType = "letter_revealed"
+	EventHintDenied     EventType = "hint_denied"
+	EventTurnTimedOut   EventType = "turn_timed_out"
+	EventWon            EventType = "won"
+	EventLost           EventType = "lost"
+	// EventAbandoned is the result of a game stopped before it was over.
+	EventAbandoned EventType = "abandoned"
+)
+
+// GuessResult is the outcome of a single guess: EventAlreadyGuessed, EventHit or EventMiss,
+// of a request for help: EventHintUnlocked, EventLetterRevealed or EventHintDenied,
+// or EventTurnTimedOut for a guess not made in time.
+type GuessResult struct {
+	Outcome   EventType
+	Letter    rune
+	Positions []int
+}
+
+// GameEvent is a machine-readable description of a change in the game together with the game state at the moment it happened.
+type GameEvent struct {
+	Type      EventType `json:"type"`
+	Player    string    `json:"player,omitempty"`
+	Letter    string    `json:"letter,omitempty"`
+	Positions []int     `json:"positions,omitempty"`
+	GameSnapshot
+}
+
+func NewGameEvent(eventType EventType, game *Game) GameEvent {
+	return GameEvent{
+		Type:         eventType,
+		GameSnapshot: game.Snapshot(),
+	}
+}
@@ -1,35 +1,64 @@
 package domain
 
 import (
+	"crypto/rand"
+	"math/big"
+	"slices"
 	"strings"
+	"time"
 )
 
+// MaxAttempts is the number of attempts for difficulties without configured attempts.
 const MaxAttempts int = 7
 
 type Game struct {
 	wordAndHint WordHintPair
 	category    Category
 	difficulty  Difficulty
+	// language is the language of the word, which decides the alphabet the player picks letters from.
+	language    Language
 	guesses     map[rune]bool
+	history     []rune
 	attempts    int
 	maxAttempts int
+	hintPolicy  HintPolicy
+	// hintsRequested is the number of hints the player has paid for under an on-demand policy.
+	hintsRequested int
+	// clock measures the time limit, which runs from startedAt; timeUp is set once it has passed.
+	clock       Clock
+	timeLimit   time.Duration
+	startedAt   time.Time
+	timeUp      bool
+	turnTimeout time.Duration
+	subscribers map[int]func(event GameEvent)
+	nextID      int
 }
 
 func NewGame(wordAndHint WordHintPair, ctg Category, diff Difficulty) (*Game, error) {
 	wordAndHint.Word = strings.ToLower(wordAndHint.Word)
-	wordAndHint.Hint = strings.ToLower(wordAndHint.Hint)
+	wordAndHint.Hints = wordAndHint.AllHints()
 
-	if wordAndHint.Word == "" || wordAndHint.Hint == "" || ctg == "" || diff == "" {
+	if wordAndHint.Word == "" || len(wordAndHint.Hints) == 0 || ctg == "" || diff == "" {
 		return nil, &InvalidLengthError{Message: "Invalid game parameters"}
 	}
 
+	for i, hint := range wordAndHint.Hints {
+		wordAndHint.Hints[i] = strings.ToLower(hint)
+	}
+
+	wordAndHint.Hint = wordAndHint.Hints[0]
+
 	return &Game{
 		wordAndHint: wordAndHint,
 		category:    ctg,
 		difficulty:  diff,
+		language:    wordAndHint.Language,
 		guesses:     make(map[rune]bool),
 		attempts:    0,
-		maxAttempts: max(1, MaxAttempts),
+		maxAttempts: max(1, AttemptsForDifficulty(diff)),
+		hintPolicy:  DefaultHintPolicy,
+		clock:       SystemClock{},
+		subscribers: make(map[int]func(event GameEvent)),
 	}, nil
 }
 
@@ -41,6 +70,15 @@ func (game *Game) GetCategory() Category {
 	return game.category
 }
 
+func (game *Game) GetLanguage() Language {
+	return game.language
+}
+
+// SetLanguage sets the language of the word, e.g. the language of its word pack when the word has none.
+func (game *Game) SetLanguage(lang Language) {
+	game.language = lang
+}
+
 func (game *Game) GetWordAndHint() WordHintPair {
 	return game.wordAndHint
 }
@@ -57,8 +95,37 @@ func (game *Game) GetGuesses() map[rune]bool {
 	return game.guesses
 }
 
+// GetGuessHistory returns the guessed letters in the order they were guessed.
+func (game *Game) GetGuessHistory() []rune {
+	return slices.Clone(game.history)
+}
+
+// GetHits returns the guessed letters that are in the word, in the order they were guessed.
+func (game *Game) GetHits() []rune {
+	return slices.DeleteFunc(game.GetGuessHistory(), func(letter rune) bool {
+		return !strings.ContainsRune(game.wordAndHint.Word, letter)
+	})
+}
+
+// GetMisses returns the guessed letters that are not in the word, in the order they were guessed.
+func (game *Game) GetMisses() []rune {
+	return slices.DeleteFunc(game.GetGuessHistory(), func(letter rune) bool {
+		return strings.ContainsRune(game.wordAndHint.Word, letter)
+	})
+}
+
+// SetGuesses replaces the guessed letters; their history is rebuilt in alphabetical order.
 func (game *Game) SetGuesses(guesses map[rune]bool) {
 	game.guesses = guesses
+	game.history = game.history[:0]
+
+	for letter, guessed := range guesses {
+		if guessed {
+			game.history = append(game.history, letter)
+		}
+	}
+
+	slices.Sort(game.history)
 }
 
 func (game *Game) SetAttempts(attempts int) {
@@ -69,13 +136,207 @@ func (game *Game) SetMaxAttempts(maxAttempts int) {
 	game.maxAttempts = maxAttempts
 }
 
-func (game *Game) GameIsOver() (isOver bool, message string) {
+func (game *Game) GetHintPolicy() HintPolicy {
+	return game.hintPolicy
+}
+
+func (game *Game) SetHintPolicy(policy HintPolicy) {
+	game.hintPolicy = policy
+}
+
+// SetClock replaces the clock the time limit is measured with, e.g. with a fake one in tests.
+func (game *Game) SetClock(clock Clock) {
+	game.clock = clock
+}
+
+// SetTimeLimit gives the player limit to finish the game, counting from now; zero removes the limit.
+// The game is not lost until ExpireIfTimeIsUp is called after the limit has passed.
+func (game *Game) SetTimeLimit(limit time.Duration) {
+	game.timeLimit = limit
+	game.startedAt = game.clock.Now()
+}
+
+// GetTimeLeft returns the time left to finish the game, zero once it is up, and whether the game has a time limit.
+func (game *Game) GetTimeLeft() (left time.Duration, limited bool) {
+	if game.timeLimit <= 0 {
+		return 0, false
+	}
+
+	return max(0, game.timeLimit-game.clock.Now().Sub(game.startedAt)), true
+}
+
+// IsTimeUp reports whether the game was lost because its time limit had passed.
+func (game *Game) IsTimeUp() bool {
+	return game.timeUp
+}
+
+// ExpireIfTimeIsUp ends the game as lost when its time limit has passed, emitting EventLost.
+// It reports whether the game is over because of the time limit.
+func (game *Game) ExpireIfTimeIsUp() bool {
+	if gameIsOver, _ := game.GameIsOver(); gameIsOver {
+		return game.timeUp
+	}
+
+	if left, limited := game.GetTimeLeft(); !limited || left > 0 {
+		return false
+	}
+
+	game.timeUp = true
+	game.emit(GuessResult{Outcome: EventLost})
+
+	return true
+}
+
+func (game *Game) GetTurnTimeout() time.Duration {
+	return game.turnTimeout
+}
+
+// SetTurnTimeout gives the player timeout to make each guess, see TurnTimedOut; zero removes the limit.
+func (game *Game) SetTurnTimeout(timeout time.Duration) {
+	game.turnTimeout = timeout
+}
+
+// TurnTimedOut charges an attempt for the guess the player did not make in time. It emits EventTurnTimedOut,
+// followed by EventHintUnlocked or EventLost when they happen.
+func (game *Game) TurnTimedOut() GuessResult {
+	result := GuessResult{Outcome: EventTurnTimedOut}
+
+	if gameIsOver, _ := game.GameIsOver(); gameIsOver {
+		return result
+	}
+
+	hintsUnlocked := game.unlockedHints()
+	game.attempts++
+
+	game.emit(result)
+
+	if game.unlockedHints() > hintsUnlocked {
+		game.emit(GuessResult{Outcome: EventHintUnlocked})
+	}
+
+	if gameIsOver, gameResult := game.GameIsOver(); gameIsOver {
+		game.emit(GuessResult{Outcome: gameResult})
+	}
+
+	return result
+}
+
+// IsHintAvailable reports whether the hint policy lets the player see at least the first hint.
+func (game *Game) IsHintAvailable() bool {
+	return game.unlockedHints() > 0
+}
+
+// GetUnlockedHints returns the hints the hint policy lets the player see, from the vaguest to the most helpful.
+func (game *Game) GetUnlockedHints() []string {
+	return slices.Clone(game.wordAndHint.Hints[:game.unlockedHints()])
+}
+
+// unlockedHints returns how many hints are shown. Automatic policies show the first hint at their threshold
+// and the next ones as the player keeps missing: after:N one per miss, half spread over the attempts left.
+func (game *Game) unlockedHints() int {
+	total := len(game.wordAndHint.Hints)
+
+	switch game.hintPolicy.Mode {
+	case HintModeNever, HintModeReveal:
+		return 0
+	case HintModeOnDemand:
+		return min(total, game.hintsRequested)
+	case HintModeAfter:
+		misses := len(game.GetMisses())
+		if misses < game.hintPolicy.Misses {
+			return 0
+		}
+
+		return min(total, misses-game.hintPolicy.Misses+1)
+	default:
+		threshold := game.maxAttempts / 2
+		if game.attempts < threshold {
+			return 0
+		}
+
+		step := max(1, (game.maxAttempts-threshold)/total)
+
+		return min(total, 1+(game.attempts-threshold)/step)
+	}
+}
+
+// RequestHint gives the player the help of an on-demand policy at the cost of an attempt:
+// the next hint for HintModeOnDemand or a random hidden letter for HintModeReveal.
+// It emits the outcome, followed by EventWon or EventLost when they happen.
+func (game *Game) RequestHint() GuessResult {
+	result := GuessResult{Outcome: EventHintDenied}
+
+	if gameIsOver, _ := game.GameIsOver(); gameIsOver || !game.hintPolicy.IsOnDemand() ||
+		(game.hintPolicy.Mode == HintModeOnDemand && game.hintsRequested >= len(game.wordAndHint.Hints)) {
+		game.emit(result)
+		return result
+	}
+
+	game.attempts++
+
+	if game.hintPolicy.Mode == HintModeOnDemand {
+		game.hintsRequested++
+		result.Outcome = EventHintUnlocked
+	} else {
+		result.Outcome = EventLetterRevealed
+		result.Letter = game.randomHiddenLetter()
+		game.guesses[result.Letter] = true
+		game.history = append(game.history, result.Letter)
+
+		for position, wordLetter := range []rune(game.wordAndHint.Word) {
+			if wordLetter == result.Letter {
+				result.Positions = append(result.Positions, position)
+			}
+		}
+	}
+
+	game.emit(result)
+
+	if gameIsOver, gameResult := game.GameIsOver(); gameIsOver {
+		game.emit(GuessResult{Outcome: gameResult, Letter: result.Letter})
+	}
+
+	return result
+}
+
+// randomHiddenLetter returns a letter of the word that has not been guessed yet; the game must not be won.
+func (game *Game) randomHiddenLetter() rune {
+	var hidden []rune
+
+	for _, letter := range game.wordAndHint.Word {
+		if letter != ' ' && !game.guesses[letter] && !slices.Contains(hidden, letter) {
+			hidden = append(hidden, letter)
+		}
+	}
+
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(len(hidden))))
+	if err != nil {
+		return hidden[0]
+	}
+
+	return hidden[index.Int64()]
+}
+
+// Subscribe registers handler to be called with every event the game emits.
+// The returned function unsubscribes it.
+func (game *Game) Subscribe(handler func(event GameEvent)) (unsubscribe func()) {
+	game.nextID++
+	subscriberID := game.nextID
+	game.subscribers[subscriberID] = handler
+
+	return func() {
+		delete(game.subscribers, subscriberID)
+	}
+}
+
+// GameIsOver reports whether the game is over and, if so, whether it was EventWon or EventLost.
+func (game *Game) GameIsOver() (isOver bool, result EventType) {
 	if game.WordGuessed() {
-		return true, "Word guessed. You win!"
+		return true, EventWon
 	}
 
-	if game.attempts >= game.maxAttempts {
-		return true, "Max attempts reached. You lose! \n" + "Word: " + game.wordAndHint.Word
+	if game.attempts >= game.maxAttempts || game.timeUp {
+		return true, EventLost
 	}
 
 	return false, ""
@@ -109,18 +370,55 @@ func (game *Game) WordGuessed() bool {
 	return true
 }
 
-func (game *Game) LetterGuessed(letter rune) string {
+// LetterGuessed applies a guess and emits its outcome, followed by EventHintUnlocked, EventWon or EventLost when they happen.
+func (game *Game) LetterGuessed(letter rune) GuessResult {
+	result := GuessResult{Outcome: EventAlreadyGuessed, Letter: letter}
+
 	if game.guesses[letter] {
-		return "Letter already guessed"
+		game.emit(result)
+		return result
 	}
 
+	hintsUnlocked := game.unlockedHints()
 	game.guesses[letter] = true
-	letterGuessed := strings.Contains(game.wordAndHint.Word, string(letter))
+	game.history = append(game.history, letter)
 
-	if !letterGuessed {
+	for position, wordLetter := range []rune(game.wordAndHint.Word) {
+		if wordLetter == letter {
+			result.Positions = append(result.Positions, position)
+		}
+	}
+
+	if len(result.Positions) == 0 {
 		game.attempts++
-		return "Letter not in word"
+		result.Outcome = EventMiss
+	} else {
+		result.Outcome = EventHit
+	}
+
+	game.emit(result)
+
+	if game.unlockedHints() > hintsUnlocked {
+		game.emit(GuessResult{Outcome: EventHintUnlocked, Letter: letter})
+	}
+
+	if gameIsOver, gameResult := game.GameIsOver(); gameIsOver {
+		game.emit(GuessResult{Outcome: gameResult, Letter: letter})
 	}
 
-	return "Letter guessed"
+	return result
+}
+
+func (game *Game) emit(result GuessResult) {
+	if len(game.subscribers) == 0 {
+		return
+	}
+
+	event := NewGameEvent(result.Outcome, game)
+	event.Letter = string(result.Letter)
+	event.Positions = result.Positions
+
+	for _, handler := range game.subscribers {
+		handler(event)
+	}
 }
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type HintMode string
+
+const (
+	// HintModeHalf shows the hint once half of the attempts are used.
+	HintModeHalf HintMode = "half"
+	// HintModeNever never shows the hint.
+	HintModeNever HintMode = "never"
+	// HintModeOnDemand shows the hint when the player asks for it, at the cost of an attempt.
+	HintModeOnDemand HintMode = "on-demand"
+	// HintModeAfter shows the hint after HintPolicy.Misses misses.
+	HintModeAfter HintMode = "after"
+	// HintModeReveal opens a random letter of the word instead of the hint when the player asks for it,
+	// at the cost of an attempt.
+	HintModeReveal HintMode = "reveal"
+)
+
+// HintPolicy is the rule of the game deciding when the player gets help.
+type HintPolicy struct {
+	Mode   HintMode
+	Misses int
+}
+
+var DefaultHintPolicy = HintPolicy{Mode: HintModeHalf}
+
+// ParseHintPolicy parses a policy written as "half", "never", "on-demand", "reveal" or "after:N".
+func ParseHintPolicy(value string) (HintPolicy, error) {
+	mode, argument, hasArgument := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+
+	switch HintMode(mode) {
+	case HintModeHalf, HintModeNever, HintModeOnDemand, HintModeReveal:
+		if hasArgument {
+			return HintPolicy{}, &HintPolicyError{Message: fmt.Sprintf("hint policy %q takes no argument", mode)}
+		}
+
+		return HintPolicy{Mode: HintMode(mode)}, nil
+	case HintModeAfter:
+		misses, err := strconv.Atoi(argument)
+		if err != nil || misses < 1 {
+			return HintPolicy{}, &HintPolicyError{Message: fmt.Sprintf("hint policy %q needs a positive number of misses", value)}
+		}
+
+		return HintPolicy{Mode: HintModeAfter, Misses: misses}, nil
+	default:
+		return HintPolicy{}, &HintPolicyError{Message: fmt.Sprintf("unknown hint policy %q", value)}
+	}
+}
+
+// IsOnDemand reports whether the player has to ask for help with RequestHint.
+func (policy HintPolicy) IsOnDemand() bool {
+	return policy.Mode == HintModeOnDemand || policy.Mode == HintModeReveal
+}
@@ -0,0 +1,8 @@
+package domain
+
+type Language string
+
+const (
+	LanguageEnglish Language = "en"
+	LanguageRussian Language = "ru"
+)
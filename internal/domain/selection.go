@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+type SelectionStrategy string
+
+const (
+	// SelectionUniformCategories gives every difficulty and category the same chance, whatever their size.
+	SelectionUniformCategories SelectionStrategy = "categories"
+	// SelectionUniformWords gives every word the same chance, so large categories are picked more often.
+	SelectionUniformWords SelectionStrategy = "words"
+	// SelectionWeighted picks difficulties and categories in proportion to SelectionWeights.
+	SelectionWeighted SelectionStrategy = "weights"
+)
+
+// SelectionWeights are the relative chances of difficulties and categories for SelectionWeighted.
+// Missing entries weigh 1, zero excludes the entry.
+type SelectionWeights struct {
+	Difficulties map[Difficulty]int `json:"difficulties"`
+	Categories   map[Category]int   `json:"categories"`
+}
+
+// Or returns the weights with the difficulties or the categories they do not set taken from fallback,
+// e.g. the weights of the config over those of the word pack.
+func (weights SelectionWeights) Or(fallback SelectionWeights) SelectionWeights {
+	if weights.Difficulties == nil {
+		weights.Difficulties = fallback.Difficulties
+	}
+
+	if weights.Categories == nil {
+		weights.Categories = fallback.Categories
+	}
+
+	return weights
+}
+
+func (weights SelectionWeights) difficulty(diff Difficulty) int {
+	for weightDiff, weight := range weights.Difficulties {
+		if strings.EqualFold(string(weightDiff), string(diff)) {
+			return weight
+		}
+	}
+
+	return 1
+}
+
+func (weights SelectionWeights) category(ctg Category) int {
+	for weightCtg, weight := range weights.Categories {
+		if strings.EqualFold(string(weightCtg), string(ctg)) {
+			return weight
+		}
+	}
+
+	return 1
+}
+
+// selectionWeight returns the chance of the difficulty, or of the category of it when ctg is set, to be picked.
+func (dwp *DefaultWordProvider) selectionWeight(diff Difficulty, ctg Category) int {
+	switch dwp.Strategy {
+	case SelectionUniformWords:
+		return dwp.CountWords(diff, ctg)
+	case SelectionWeighted:
+		if ctg != "" {
+			return dwp.Weights.category(ctg)
+		}
+
+		return dwp.Weights.difficulty(diff)
+	default:
+		return 1
+	}
+}
+
+// pickWeighted returns a random index of weights, each index chosen in proportion to its weight.
+func pickWeighted(weights []int) (int, error) {
+	total := 0
+	for _, weight := range weights {
+		total += max(0, weight)
+	}
+
+	if total == 0 {
+		return 0, &NotFoundError{Message: "no candidate with a positive weight to get random value"}
+	}
+
+	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(total)))
+	if err != nil {
+		return 0, err
+	}
+
+	point := int(nBig.Int64())
+
+	for i, weight := range weights {
+		point -= max(0, weight)
+		if point < 0 {
+			return i, nil
+		}
+	}
+
+	return len(weights) - 1, nil
+}
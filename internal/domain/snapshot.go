@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// GameSnapshot is a read-only copy of the game state that front ends draw from.
+// The answer is only filled in once the game is over.
+type GameSnapshot struct {
+	Category    Category   `json:"category"`
+	Difficulty  Difficulty `json:"difficulty"`
+	Language    Language   `json:"language,omitempty"` // Of the word; empty when it is not known.
+	Word        string     `json:"word"`
+	Guesses     []string   `json:"guesses"` // In the order they were guessed.
+	Hits        []string   `json:"hits"`
+	Misses      []string   `json:"misses"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"maxAttempts"`
+	Hint        string     `json:"hint,omitempty"`     // The most helpful of the unlocked hints.
+	Hints       []string   `json:"hints,omitempty"`    // Unlocked hints, from the vaguest to the most helpful.
+	TimeLeft    int        `json:"timeLeft,omitempty"` // Seconds left to finish the game, rounded up; zero without a time limit.
+	Result      EventType  `json:"result,omitempty"`
+	Answer      string     `json:"answer,omitempty"`
+	// Details of the answer, filled in with it.
+	Definition string   `json:"definition,omitempty"`
+	Example    string   `json:"example,omitempty"`
+	Source     string   `json:"source,omitempty"`
+	Emoji      string   `json:"emoji,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+func (game *Game) Snapshot() GameSnapshot {
+	snapshot := GameSnapshot{
+		Category:    game.category,
+		Difficulty:  game.difficulty,
+		Language:    game.language,
+		Word:        game.GetWordWithGuesses(),
+		Guesses:     lettersToStrings(game.history),
+		Hits:        lettersToStrings(game.GetHits()),
+		Misses:      lettersToStrings(game.GetMisses()),
+		Attempts:    game.attempts,
+		MaxAttempts: game.maxAttempts,
+	}
+
+	if hints := game.GetUnlockedHints(); len(hints) > 0 {
+		snapshot.Hints = hints
+		snapshot.Hint = hints[len(hints)-1]
+	}
+
+	if left, limited := game.GetTimeLeft(); limited {
+		snapshot.TimeLeft = int((left + time.Second - 1) / time.Second)
+	}
+
+	if gameIsOver, result := game.GameIsOver(); gameIsOver {
+		snapshot.Result = result
+		snapshot.Answer = game.wordAndHint.Word
+		snapshot.Definition = game.wordAndHint.Definition
+		snapshot.Example = game.wordAndHint.Example
+		snapshot.Source = game.wordAndHint.Source
+		snapshot.Emoji = game.wordAndHint.Emoji
+		snapshot.Tags = game.wordAndHint.Tags
+	}
+
+	return snapshot
+}
+
+// IsOver reports whether the snapshot was taken after the game had ended.
+func (snapshot *GameSnapshot) IsOver() bool {
+	return snapshot.Result != ""
+}
+
+func lettersToStrings(letters []rune) []string {
+	strs := make([]string, 0, len(letters))
+	for _, letter := range letters {
+		strs = append(strs, string(letter))
+	}
+
+	return strs
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "time"
+
+// GameRecord is the result of a finished game, kept to show the statistics of the player.
+type GameRecord struct {
+	FinishedAt  time.Time  `json:"finishedAt"`
+	Word        string     `json:"word"`
+	Category    Category   `json:"category"`
+	Difficulty  Difficulty `json:"difficulty"`
+	Result      EventType  `json:"result"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"maxAttempts"`
+}
+
+// NewGameRecord returns the record of the game; a game that is not over is recorded as EventAbandoned.
+func NewGameRecord(game *Game, finishedAt time.Time) GameRecord {
+	gameIsOver, result := game.GameIsOver()
+	if !gameIsOver {
+		result = EventAbandoned
+	}
+
+	return GameRecord{
+		FinishedAt:  finishedAt,
+		Word:        game.wordAndHint.Word,
+		Category:    game.category,
+		Difficulty:  game.difficulty,
+		Result:      result,
+		Attempts:    game.attempts,
+		MaxAttempts: game.maxAttempts,
+	}
+}
+
+// GameStats sums up the records of the games played.
+type GameStats struct {
+	Played        int
+	Won           int
+	CurrentStreak int // Games won in a row up to the last one.
+	BestStreak    int
+	ByDifficulty  map[Difficulty]DifficultyStats
+}
+
+type DifficultyStats struct {
+	Played int
+	Won    int
+}
+
+// SummarizeGames returns the statistics of the records, which are expected in the order the games were played.
+func SummarizeGames(records []GameRecord) GameStats {
+	stats := GameStats{ByDifficulty: make(map[Difficulty]DifficultyStats)}
+
+	for _, record := range records {
+		diffStats := stats.ByDifficulty[record.Difficulty]
+		diffStats.Played++
+		stats.Played++
+
+		if record.Result == EventWon {
+			diffStats.Won++
+			stats.Won++
+			stats.CurrentStreak++
+			stats.BestStreak = max(stats.BestStreak, stats.CurrentStreak)
+		} else {
+			stats.CurrentStreak = 0
+		}
+
+		stats.ByDifficulty[record.Difficulty] = diffStats
+	}
+
+	return stats
+}
+
+// Lost returns the number of games that were not won.
+func (stats GameStats) Lost() int {
+	return stats.Played - stats.Won
+}
+
+// WinRate returns the share of the games won in percent, rounded down.
+func (stats GameStats) WinRate() int {
+	if stats.Played == 0 {
+		return 0
+	}
+
+	return stats.Won * 100 / stats.Played
+}
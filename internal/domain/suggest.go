@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// RandomValue asks for a difficulty or category picked at random.
+const RandomValue = "random"
+
+// maxSuggestions is the number of closest values a typo is reported with.
+const maxSuggestions = 3
+
+// Suggest returns up to three candidates close to value, the closest first: those within a few edits of it,
+// ignoring case, and those starting with it.
+func Suggest[T ~string](value T, candidates []T) []T {
+	lowerValue := strings.ToLower(string(value))
+	maxDistance := max(2, utf8.RuneCountInString(lowerValue)/3)
+	distances := make(map[T]int)
+
+	for _, candidate := range candidates {
+		lowerCandidate := strings.ToLower(string(candidate))
+
+		distance := levenshtein(lowerValue, lowerCandidate)
+		if distance <= maxDistance || (utf8.RuneCountInString(lowerValue) >= 2 && strings.HasPrefix(lowerCandidate, lowerValue)) {
+			distances[candidate] = distance
+		}
+	}
+
+	suggestions := make([]T, 0, len(distances))
+	for candidate := range distances {
+		suggestions = append(suggestions, candidate)
+	}
+
+	slices.SortFunc(suggestions, func(a, b T) int {
+		if distances[a] != distances[b] {
+			return distances[a] - distances[b]
+		}
+
+		return strings.Compare(string(a), string(b))
+	})
+
+	return suggestions[:min(len(suggestions), maxSuggestions)]
+}
+
+// NewOptionError returns the error of an option set to a value other than the available ones, suggesting
+// the closest of them or, when none is close, listing them all.
+func NewOptionError(option, value string, available []string) *UnknownValueError {
+	suggestions := Suggest(value, available)
+	message := fmt.Sprintf("%s '%s' is not available", option, value)
+
+	if len(suggestions) > 0 {
+		message += fmt.Sprintf(", did you mean %s?", strings.Join(suggestions, ", "))
+	} else {
+		message += ", available values: " + strings.Join(available, ", ")
+	}
+
+	return &UnknownValueError{Message: message, Suggestions: suggestions}
+}
+
+// levenshtein returns the number of single letter insertions, deletions and substitutions turning a into b.
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i, sourceLetter := range source {
+		current[0] = i + 1
+
+		for j, targetLetter := range target {
+			substitution := previous[j]
+			if sourceLetter != targetLetter {
+				substitution++
+			}
+
+			current[j+1] = min(previous[j+1]+1, current[j]+1, substitution)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
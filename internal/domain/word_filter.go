@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// WordFilter narrows down the words the provider picks from; zero fields do not filter.
+type WordFilter struct {
+	MinLength      int
+	MaxLength      int
+	ExcludeLetters string
+	Tag            string
+	// NoSpaces drops phrases, keeping single words only.
+	NoSpaces bool
+	// ExcludeWords drops the words already played, e.g. in a blitz.
+	ExcludeWords []string
+}
+
+type wordFilterRule struct {
+	name string
+	keep func(pair WordHintPair) bool
+}
+
+// IsZero reports whether the filter keeps every word.
+func (filter WordFilter) IsZero() bool {
+	return filter.MinLength == 0 && filter.MaxLength == 0 && filter.ExcludeLetters == "" && filter.Tag == "" && !filter.NoSpaces &&
+		len(filter.ExcludeWords) == 0
+}
+
+// Apply returns the words passing every rule of the filter, or NotFoundError naming the rule that left no words.
+func (filter WordFilter) Apply(wordAndHintPairs []WordHintPair) ([]WordHintPair, error) {
+	for _, rule := range filter.rules() {
+		kept := make([]WordHintPair, 0, len(wordAndHintPairs))
+
+		for _, pair := range wordAndHintPairs {
+			if rule.keep(pair) {
+				kept = append(kept, pair)
+			}
+		}
+
+		if len(kept) == 0 {
+			return nil, &NotFoundError{
+				Message: fmt.Sprintf("no words left after filter %s (%d words before it)", rule.name, len(wordAndHintPairs)),
+			}
+		}
+
+		wordAndHintPairs = kept
+	}
+
+	return wordAndHintPairs, nil
+}
+
+func (filter WordFilter) rules() []wordFilterRule {
+	var rules []wordFilterRule
+
+	if filter.MinLength > 0 {
+		rules = append(rules, wordFilterRule{
+			name: fmt.Sprintf("min-length=%d", filter.MinLength),
+			keep: func(pair WordHintPair) bool { return wordLength(pair.Word) >= filter.MinLength },
+		})
+	}
+
+	if filter.MaxLength > 0 {
+		rules = append(rules, wordFilterRule{
+			name: fmt.Sprintf("max-length=%d", filter.MaxLength),
+			keep: func(pair WordHintPair) bool { return wordLength(pair.Word) <= filter.MaxLength },
+		})
+	}
+
+	if filter.ExcludeLetters != "" {
+		rules = append(rules, wordFilterRule{
+			name: fmt.Sprintf("exclude-letters=%s", filter.ExcludeLetters),
+			keep: func(pair WordHintPair) bool {
+				return !strings.ContainsAny(strings.ToLower(pair.Word), strings.ToLower(filter.ExcludeLetters))
+			},
+		})
+	}
+
+	if filter.Tag != "" {
+		rules = append(rules, wordFilterRule{
+			name: fmt.Sprintf("tag=%s", filter.Tag),
+			keep: func(pair WordHintPair) bool { return pair.HasTag(filter.Tag) },
+		})
+	}
+
+	if filter.NoSpaces {
+		rules = append(rules, wordFilterRule{
+			name: "words-with-spaces=false",
+			keep: func(pair WordHintPair) bool { return !strings.Contains(pair.Word, " ") },
+		})
+	}
+
+	if len(filter.ExcludeWords) > 0 {
+		rules = append(rules, wordFilterRule{
+			name: fmt.Sprintf("exclude-words=%d", len(filter.ExcludeWords)),
+			keep: func(pair WordHintPair) bool { return !slices.Contains(filter.ExcludeWords, strings.ToLower(pair.Word)) },
+		})
+	}
+
+	return rules
+}
+
+// wordLength counts the letters of the word, so the spaces of a phrase do not make it longer.
+func wordLength(word string) int {
+	return utf8.RuneCountInString(strings.ReplaceAll(word, " ", ""))
+}
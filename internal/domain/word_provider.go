@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"crypto/rand"
@@ -11,12 +12,64 @@ import (
 type WordHintPair struct {
 	Word string
 	Hint string
+	// Hints are ordered from the vaguest to the most helpful; a word pack may give them instead of or together with Hint.
+	Hints []string
+	// The optional metadata below is shown once the game is over, so players learn the word they played.
+	Definition string
+	Example    string
+	Language   Language
+	Tags       []string
+	Source     string
+	Emoji      string
+}
+
+// HasTag reports whether the word is tagged with tag, ignoring case.
+func (pair WordHintPair) HasTag(tag string) bool {
+	return slices.ContainsFunc(pair.Tags, func(wordTag string) bool {
+		return strings.EqualFold(wordTag, tag)
+	})
+}
+
+// AllHints returns the hints of the word in the order they are revealed: Hint first, followed by Hints.
+func (pair WordHintPair) AllHints() []string {
+	hints := make([]string, 0, len(pair.Hints)+1)
+
+	if pair.Hint != "" {
+		hints = append(hints, pair.Hint)
+	}
+
+	for _, hint := range pair.Hints {
+		if hint != "" && !slices.Contains(hints, hint) {
+			hints = append(hints, hint)
+		}
+	}
+
+	return hints
 }
 
 type DefaultWordProvider struct {
 	Words           map[Difficulty]map[Category][]WordHintPair
 	AllDifficulties []Difficulty
 	AllCategories   []Category
+	Language        Language
+	// Attempts overrides the attempts per difficulty set by the word pack.
+	Attempts map[Difficulty]int
+	// Filter narrows down the words picked at random.
+	Filter WordFilter
+	// Strategy and Weights decide how likely each difficulty and category is to be picked at random.
+	Strategy SelectionStrategy
+	Weights  SelectionWeights
+}
+
+// GetAttempts returns the attempts the word pack sets for diff, reporting whether it sets any.
+func (dwp *DefaultWordProvider) GetAttempts(diff Difficulty) (int, bool) {
+	for packDiff, attempts := range dwp.Attempts {
+		if strings.EqualFold(string(packDiff), string(diff)) {
+			return attempts, true
+		}
+	}
+
+	return 0, false
 }
 
 func (dwp *DefaultWordProvider) UpdateUniqueCategoriesAndDifficulties() error {
@@ -73,38 +126,56 @@ func (dwp *DefaultWordProvider) NormalizeCase() {
 
 func (dwp *DefaultWordProvider) GetRandomDifficulty() (Difficulty, error) {
 	difficulties := make([]Difficulty, 0, len(dwp.Words))
+	weights := make([]int, 0, len(dwp.Words))
+
 	for diff := range dwp.Words {
-		difficulties = append(difficulties, diff)
+		if dwp.hasMatchingWords(diff, "") {
+			difficulties = append(difficulties, diff)
+			weights = append(weights, dwp.selectionWeight(diff, ""))
+		}
+	}
+
+	if len(difficulties) == 0 && !dwp.Filter.IsZero() {
+		return "", dwp.filterError("")
 	}
 
 	if len(difficulties) == 0 {
 		return "", &NotFoundError{Message: "no difficulty found to get random value"}
 	}
 
-	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(len(difficulties))))
+	index, err := pickWeighted(weights)
 	if err != nil {
 		return "", err
 	}
 
-	return difficulties[nBig.Int64()], nil
+	return difficulties[index], nil
 }
 
 func (dwp *DefaultWordProvider) GetRandomCategoryFromDifficulty(diff Difficulty) (Category, error) {
 	categories := make([]Category, 0, len(dwp.Words[diff]))
+	weights := make([]int, 0, len(dwp.Words[diff]))
+
 	for ctg := range dwp.Words[diff] {
-		categories = append(categories, ctg)
+		if dwp.hasMatchingWords(diff, ctg) {
+			categories = append(categories, ctg)
+			weights = append(weights, dwp.selectionWeight(diff, ctg))
+		}
+	}
+
+	if len(categories) == 0 && len(dwp.Words[diff]) > 0 && !dwp.Filter.IsZero() {
+		return "", dwp.filterError(diff)
 	}
 
 	if len(categories) == 0 {
 		return "", &NotFoundError{Message: "no category found to get random value"}
 	}
 
-	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(len(categories))))
+	index, err := pickWeighted(weights)
 	if err != nil {
 		return "", err
 	}
 
-	return categories[nBig.Int64()], nil
+	return categories[index], nil
 }
 
 func (dwp *DefaultWordProvider) GetRandomWordAndHintFromCategory(ctg Category, diff Difficulty) (WordHintPair, error) {
@@ -115,6 +186,13 @@ func (dwp *DefaultWordProvider) GetRandomWordAndHintFromCategory(ctg Category, d
 		}
 	}
 
+	words, err := dwp.Filter.Apply(words)
+	if err != nil {
+		return WordHintPair{}, &NotFoundError{
+			Message: fmt.Sprintf("No words and hints in category '%s' with difficulty '%s': %s", ctg, diff, err.Error()),
+		}
+	}
+
 	nBig, err := rand.Int(rand.Reader, big.NewInt(int64(len(words))))
 	if err != nil {
 		return WordHintPair{}, err
@@ -122,3 +200,62 @@ func (dwp *DefaultWordProvider) GetRandomWordAndHintFromCategory(ctg Category, d
 
 	return words[nBig.Int64()], nil
 }
+
+// hasMatchingWords reports whether the filter leaves any words in the difficulty, limited to the category if it is set.
+func (dwp *DefaultWordProvider) hasMatchingWords(diff Difficulty, ctg Category) bool {
+	if dwp.Filter.IsZero() {
+		return true
+	}
+
+	for category, wordAndHintPairs := range dwp.Words[diff] {
+		if ctg != "" && category != ctg {
+			continue
+		}
+
+		if _, err := dwp.Filter.Apply(wordAndHintPairs); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CountWords returns the number of words the filter leaves in the difficulty,
+// limited to the category if it is set.
+func (dwp *DefaultWordProvider) CountWords(diff Difficulty, ctg Category) int {
+	count := 0
+
+	for category, wordAndHintPairs := range dwp.Words[diff] {
+		if ctg != "" && category != ctg {
+			continue
+		}
+
+		if matching, err := dwp.Filter.Apply(wordAndHintPairs); err == nil {
+			count += len(matching)
+		}
+	}
+
+	return count
+}
+
+// filterError returns the error of the filter applied to all words of the difficulty, or of the pack if it is empty,
+// naming the rule that left no words.
+func (dwp *DefaultWordProvider) filterError(diff Difficulty) error {
+	var all []WordHintPair
+
+	for difficulty, categories := range dwp.Words {
+		if diff != "" && difficulty != diff {
+			continue
+		}
+
+		for _, wordAndHintPairs := range categories {
+			all = append(all, wordAndHintPairs...)
+		}
+	}
+
+	if _, err := dwp.Filter.Apply(all); err != nil {
+		return err
+	}
+
+	return &NotFoundError{Message: "no category has words matching all filters"}
+}
@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	artDirName      = "art"
+	minArtFrames    = 2
+	maxArtLineWidth = minMenuWidth - 2
+)
+
+type artPack struct {
+	Name   string     `json:"name"`
+	Frames [][]string `json:"frames"`
+}
+
+// FindArtPack returns the path of the art pack called name in the art directory under dir;
+// a name ending with .json is treated as a path to the pack.
+func FindArtPack(dir, name string) string {
+	if strings.HasSuffix(name, ".json") {
+		return name
+	}
+
+	return filepath.Join(dir, artDirName, name+".json")
+}
+
+// LoadArtPack reads the frames of the gallows art from a JSON file with "name" and "frames" fields.
+// Every frame must have the same number of lines and the same width, so the menu keeps its shape between guesses.
+func LoadArtPack(filePath string) ([]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		slog.Error("reading art pack", slog.String("filePath", filePath), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	var pack artPack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		slog.Error("unmarshalling art pack", slog.String("filePath", filePath), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("unmarshalling JSON: %w", err)
+	}
+
+	if err := validateArtPack(&pack); err != nil {
+		slog.Error("validating art pack", slog.String("filePath", filePath), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("validating art pack %q: %w", pack.Name, err)
+	}
+
+	frames := make([]string, 0, len(pack.Frames))
+	for _, frame := range pack.Frames {
+		frames = append(frames, strings.Join(frame, "\n"))
+	}
+
+	return frames, nil
+}
+
+func validateArtPack(pack *artPack) error {
+	if len(pack.Frames) < minArtFrames {
+		return fmt.Errorf("at least %d frames are required, got %d", minArtFrames, len(pack.Frames))
+	}
+
+	height, width := len(pack.Frames[0]), frameWidth(pack.Frames[0])
+
+	for i, frame := range pack.Frames {
+		if len(frame) != height {
+			return fmt.Errorf("frame %d has %d lines, the first frame has %d", i+1, len(frame), height)
+		}
+
+		if frameWidth(frame) != width {
+			return fmt.Errorf("frame %d is %d columns wide, the first frame is %d", i+1, frameWidth(frame), width)
+		}
+	}
+
+	if width > maxArtLineWidth {
+		return fmt.Errorf("frames are %d columns wide, at most %d fit the menu", width, maxArtLineWidth)
+	}
+
+	return nil
+}
+
+// frameWidth returns the width of the widest line of the frame.
+func frameWidth(frame []string) int {
+	width := 0
+	for _, line := range frame {
+		width = max(width, displayWidth(line))
+	}
+
+	return width
+}
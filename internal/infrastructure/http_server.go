@@ -0,0 +1,246 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+const (
+	readHeaderTimeout = 5 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// LiveRoomHandler is a room whose events can be watched by spectators.
+type LiveRoomHandler interface {
+	RoomHandler
+	Watch(send func(event domain.GameEvent)) (unwatch func())
+}
+
+type liveMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type liveGuess struct {
+	Letter string `json:"letter"`
+}
+
+// ServeRoom serves the live game page and its WebSocket endpoint on addr until the game in the room is over.
+// Connecting to /ws streams game events as JSON; adding ?name=<player> also lets the connection guess letters.
+func ServeRoom(addr string, room LiveRoomHandler) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		slog.Error("listening for HTTP connections", slog.String("addr", addr), slog.String("error", err.Error()))
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+
+	handler := NewLiveHandler(room)
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: readHeaderTimeout}
+
+	fmt.Print(Localize(MessageLiveServed, listener.Addr()))
+	slog.Info("Live game served", slog.String("addr", listener.Addr().String()))
+
+	serveErr := make(chan error, 1)
+
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		slog.Error("serving HTTP", slog.String("error", err.Error()))
+		return fmt.Errorf("serving HTTP: %w", err)
+	case <-room.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Error("shutting down HTTP server", slog.String("error", err.Error()))
+	}
+
+	handler.Close()
+
+	return nil
+}
+
+// LiveHandler serves the live game page at / and its WebSocket endpoint at /ws.
+type LiveHandler struct {
+	mux         *http.ServeMux
+	connections liveConnections
+}
+
+// NewLiveHandler returns the handler of the live game in the room.
+func NewLiveHandler(room LiveRoomHandler) *LiveHandler {
+	handler := &LiveHandler{mux: http.NewServeMux()}
+
+	handler.mux.HandleFunc("GET /{$}", serveLivePage)
+	handler.mux.HandleFunc("GET /ws", func(w http.ResponseWriter, r *http.Request) {
+		if !handler.connections.open() {
+			http.Error(w, "Game is over", http.StatusServiceUnavailable)
+			return
+		}
+
+		defer handler.connections.done()
+
+		handleLiveConnection(w, r, room)
+	})
+
+	return handler
+}
+
+func (handler *LiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler.mux.ServeHTTP(w, r)
+}
+
+// Close refuses new WebSocket connections and waits for the open ones, which end with the game in the room.
+func (handler *LiveHandler) Close() {
+	handler.connections.closeAndWait()
+}
+
+// liveConnections counts the WebSocket connections, which Shutdown does not track once they are hijacked.
+// After closeAndWait no new connections are opened, so none of them can start while it waits.
+type liveConnections struct {
+	mu     sync.Mutex
+	active sync.WaitGroup
+	closed bool
+}
+
+// open counts a new connection, or reports false when the server is closed.
+func (connections *liveConnections) open() bool {
+	connections.mu.Lock()
+	defer connections.mu.Unlock()
+
+	if connections.closed {
+		return false
+	}
+
+	connections.active.Add(1)
+
+	return true
+}
+
+func (connections *liveConnections) done() {
+	connections.active.Done()
+}
+
+// closeAndWait stops new connections from opening and waits for the open ones to end.
+func (connections *liveConnections) closeAndWait() {
+	connections.mu.Lock()
+	connections.closed = true
+	connections.mu.Unlock()
+
+	connections.active.Wait()
+}
+
+func serveLivePage(w http.ResponseWriter, r *http.Request) {
+	absPath, err := filepath.Abs(filepath.Join("..", "..", "files", "web", "index.html"))
+	if err != nil {
+		slog.Error("getting absolute path to index.html file", slog.String("error", err.Error()))
+		http.Error(w, "Page not available", http.StatusInternalServerError)
+
+		return
+	}
+
+	if _, err := os.Stat(absPath); err != nil {
+		slog.Error("reading index.html file", slog.String("error", err.Error()))
+		http.Error(w, "Page not available", http.StatusInternalServerError)
+
+		return
+	}
+
+	http.ServeFile(w, r, absPath)
+}
+
+func handleLiveConnection(w http.ResponseWriter, r *http.Request, room LiveRoomHandler) {
+	ws, err := upgradeWebsocket(w, r)
+	if err != nil {
+		slog.Error("upgrading to websocket", slog.String("error", err.Error()))
+		return
+	}
+
+	defer ws.Close()
+
+	remote := ws.conn.RemoteAddr().String()
+	slog.Info("Live connection opened", slog.String("remote", remote))
+
+	outgoing := newOutbox(remote, func(message any) error {
+		data, err := json.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("marshalling message: %w", err)
+		}
+
+		return ws.WriteMessage(data)
+	})
+	defer outgoing.close()
+
+	defer room.Watch(func(event domain.GameEvent) { outgoing.send(event) })()
+
+	sendText := func(message string) {
+		outgoing.send(liveMessage{Type: "message", Message: message})
+	}
+
+	playerID := 0
+
+	if name := strings.TrimSpace(r.URL.Query().Get("name")); name != "" {
+		playerID, err = room.Join(name, sendText)
+		if err != nil {
+			sendText(err.Error())
+			return
+		}
+
+		defer room.Leave(playerID)
+	}
+
+	defer interruptReadsWhenDone(room.Done(), ws.conn)()
+
+	readLiveGuesses(ws, playerID, room, sendText)
+}
+
+// readLiveGuesses reads guesses until the connection closes; spectators (playerID 0) may only keep the connection open.
+func readLiveGuesses(ws *websocketConn, playerID int, room RoomHandler, send func(message string)) {
+	for {
+		message, err := ws.ReadMessage()
+		if err != nil {
+			if !errors.Is(err, errWebsocketClosed) && !errors.Is(err, io.EOF) && !errors.Is(err, os.ErrDeadlineExceeded) {
+				slog.Error("reading websocket message", slog.String("error", err.Error()))
+			}
+
+			return
+		}
+
+		if playerID == 0 {
+			send(Localize(MessageSpectator))
+			continue
+		}
+
+		var guess liveGuess
+		if err := json.Unmarshal([]byte(message), &guess); err != nil {
+			guess.Letter = message
+		}
+
+		letter, ok := parseLetter(strings.TrimSpace(guess.Letter))
+		if !ok {
+			send(Localize(MessageWrongInput))
+			continue
+		}
+
+		if err := room.Guess(playerID, letter); err != nil {
+			send(err.Error())
+		}
+	}
+}
@@ -1,8 +1,17 @@
 package infrastructure_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
@@ -11,6 +20,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// discardLogger drops the records of the readers and UIs under test.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
 func TestGetLetterFromUser_success(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -89,6 +101,92 @@ func TestGetLetterFromUser_failure(t *testing.T) {
 	}
 }
 
+func TestGetLetterFromUser_command(t *testing.T) {
+	restoreStdin, err := testutils.SimulateStdinInput(":hint\nb\n")
+	require.NoError(t, err)
+	defer restoreStdin()
+
+	letter, err := infrastructure.GetLetterFromUser()
+	assert.NoError(t, err)
+	assert.Equal(t, 'b', letter)
+}
+
+func TestInputReader_success(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    rune
+		wantErr bool
+	}{
+		{
+			name:    "lowercase letter",
+			input:   "a\n",
+			want:    'a',
+			wantErr: false,
+		},
+		{
+			name:    "uppercase letter",
+			input:   "A\n",
+			want:    'a',
+			wantErr: false,
+		},
+		{
+			name:    "cyrillic letter",
+			input:   "Ж\n",
+			want:    'ж',
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := infrastructure.NewInputReader(strings.NewReader(tt.input), io.Discard, discardLogger).ReadInput(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.want, input.Letter)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, input.Letter)
+			}
+		})
+	}
+}
+
+func TestInputReader_failure(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    rune
+		wantErr bool
+	}{
+		{
+			name:    "non-letter input",
+			input:   "1\n",
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "multiple characters",
+			input:   "ab\n",
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := infrastructure.NewInputReader(strings.NewReader(tt.input), io.Discard, discardLogger).ReadInput(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.want, input.Letter)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, input.Letter)
+			}
+		})
+	}
+}
+
 func TestGameStateAfterEachInput(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -132,22 +230,69 @@ func TestGameStateAfterEachInput(t *testing.T) {
 			game, err := domain.NewGame(tt.wordAndHint, tt.category, tt.difficulty)
 			require.NoError(t, err)
 
-			var restoreStdinFuncs []func()
-
 			for i, input := range tt.inputs {
-				restoreStdin, err := testutils.SimulateStdinInput(string(input) + "\n")
-				require.NoError(t, err)
-
-				restoreStdinFuncs = append(restoreStdinFuncs, restoreStdin)
-
 				game.LetterGuessed(input)
 				assert.Equal(t, tt.expectedStates[i], game.GetWordWithGuesses())
 				assert.Equal(t, tt.expectedAttempts[i], game.GetAttempts())
 			}
+		})
+	}
+}
+
+func TestInputReader_cancelled(t *testing.T) {
+	input, typing := io.Pipe()
+	defer typing.Close()
+
+	reader := infrastructure.NewInputReader(input, io.Discard, discardLogger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := reader.ReadInput(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// The line typed after the cancellation is read by the next call.
+	go typing.Write([]byte("a\n")) //nolint:errcheck // The pipe is closed at the end of the test.
+
+	playerInput, err := reader.ReadInput(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 'a', playerInput.Letter)
+}
+
+func TestInputReader_command(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    infrastructure.Command
+		wantErr bool
+	}{
+		{
+			name:  "hint command",
+			input: ":hint\n",
+			want:  infrastructure.CommandHint,
+		},
+		{
+			name:  "hint command in uppercase",
+			input: ":HINT\n",
+			want:  infrastructure.CommandHint,
+		},
+		{
+			name:    "unknown command",
+			input:   ":quit\n",
+			wantErr: true,
+		},
+	}
 
-			for _, restore := range restoreStdinFuncs {
-				restore()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := infrastructure.NewInputReader(strings.NewReader(tt.input), io.Discard, discardLogger).ReadInput(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
 			}
+
+			assert.Equal(t, tt.want, input.Command)
 		})
 	}
 }
@@ -199,6 +344,21 @@ func TestCreateProviderFromJSONFile_failure(t *testing.T) {
 			filePath:      filepath.Join("..", "..", "files", "test", "words_invalid_test.json"),
 			expectedError: "validating JSON",
 		},
+		{
+			name:          "word without hints",
+			filePath:      filepath.Join("..", "..", "files", "test", "words_without_hints_test.json"),
+			expectedError: "validating JSON",
+		},
+		{
+			name:          "negative weight",
+			filePath:      filepath.Join("..", "..", "files", "test", "words_invalid_weights_test.json"),
+			expectedError: "validating JSON",
+		},
+		{
+			name:          "attempts less than one",
+			filePath:      filepath.Join("..", "..", "files", "test", "words_invalid_attempts_test.json"),
+			expectedError: "validating JSON",
+		},
 	}
 
 	for _, tt := range tests {
@@ -218,3 +378,665 @@ func TestCreateProviderFromJSONFile_failure(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateProviderFromJSONFile_attempts(t *testing.T) {
+	absFilePath, err := filepath.Abs(filepath.Join("..", "..", "files", "test", "words_attempts_test.json"))
+	require.NoError(t, err)
+
+	provider, err := infrastructure.CreateProviderFromJSONFile(absFilePath)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		difficulty   domain.Difficulty
+		wantAttempts int
+		wantExists   bool
+	}{
+		{
+			name:         "configured difficulty",
+			difficulty:   "easy",
+			wantAttempts: 12,
+			wantExists:   true,
+		},
+		{
+			name:         "configured difficulty in another case",
+			difficulty:   "nightmare",
+			wantAttempts: 2,
+			wantExists:   true,
+		},
+		{
+			name:       "difficulty without attempts",
+			difficulty: "hard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts, exists := provider.GetAttempts(tt.difficulty)
+			assert.Equal(t, tt.wantExists, exists)
+			assert.Equal(t, tt.wantAttempts, attempts)
+		})
+	}
+}
+
+func TestCreateProviderFromJSONFile_hints(t *testing.T) {
+	absFilePath, err := filepath.Abs(filepath.Join("..", "..", "files", "test", "words_hints_test.json"))
+	require.NoError(t, err)
+
+	provider, err := infrastructure.CreateProviderFromJSONFile(absFilePath)
+	require.NoError(t, err)
+
+	hints := make(map[string][]string)
+	for _, pair := range provider.Words["easy"]["fruits"] {
+		hints[pair.Word] = pair.AllHints()
+	}
+
+	assert.Equal(t, map[string][]string{
+		"apple":  {"A fruit", "Keeps the doctor away"},
+		"banana": {"Long, yellow fruit", "Monkeys love it"},
+	}, hints)
+}
+
+func TestCreateProviderFromJSONFile_weights(t *testing.T) {
+	absFilePath, err := filepath.Abs(filepath.Join("..", "..", "files", "test", "words_weights_test.json"))
+	require.NoError(t, err)
+
+	provider, err := infrastructure.CreateProviderFromJSONFile(absFilePath)
+	require.NoError(t, err)
+
+	provider.Strategy = domain.SelectionWeighted
+
+	// Easy and animals weigh zero, so only the cars of hard can be picked.
+	for range 10 {
+		difficulty, err := provider.GetRandomDifficulty()
+		require.NoError(t, err)
+		assert.Equal(t, domain.Difficulty("hard"), difficulty)
+
+		category, err := provider.GetRandomCategoryFromDifficulty(difficulty)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Category("cars"), category)
+	}
+}
+
+func TestCreateProviderFromJSONFile_metadata(t *testing.T) {
+	absFilePath, err := filepath.Abs(filepath.Join("..", "..", "files", "test", "words_metadata_test.json"))
+	require.NoError(t, err)
+
+	provider, err := infrastructure.CreateProviderFromJSONFile(absFilePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.WordHintPair{
+		Word:       "apple",
+		Hint:       "A fruit",
+		Definition: "A round fruit",
+		Example:    "An apple a day.",
+		Language:   domain.LanguageEnglish,
+		Tags:       []string{"Food", "vocabulary"},
+		Source:     "Test dictionary",
+		Emoji:      "🍎",
+	}, provider.Words["easy"]["fruits"][0])
+
+	provider.Filter = domain.WordFilter{Tag: "food"}
+
+	difficulty, err := provider.GetRandomDifficulty()
+	require.NoError(t, err)
+	assert.Equal(t, domain.Difficulty("easy"), difficulty)
+
+	category, err := provider.GetRandomCategoryFromDifficulty(difficulty)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Category("fruits"), category)
+
+	provider.Filter = domain.WordFilter{Tag: "missing"}
+
+	_, err = provider.GetRandomDifficulty()
+	assert.IsType(t, &domain.NotFoundError{}, err)
+}
+
+func TestGameOverMessage(t *testing.T) {
+	defer infrastructure.SetLanguage(infrastructure.GetLanguage())
+
+	infrastructure.SetLanguage(domain.LanguageEnglish)
+
+	tests := []struct {
+		name    string
+		pair    domain.WordHintPair
+		guesses []rune
+		want    string
+	}{
+		{
+			name:    "win without metadata",
+			pair:    domain.WordHintPair{Word: "ab", Hint: "hint"},
+			guesses: []rune{'a', 'b'},
+			want:    "Word guessed. You win!",
+		},
+		{
+			name: "loss with metadata",
+			pair: domain.WordHintPair{
+				Word:       "ab",
+				Hint:       "hint",
+				Definition: "The first letters",
+				Example:    "Learn your ab.",
+				Source:     "Test dictionary",
+				Emoji:      "🔤",
+			},
+			guesses: []rune{'x', 'y', 'z'},
+			want: "Max attempts reached. You lose! \nWord: ab" +
+				"\n🔤 Definition: The first letters\nExample: Learn your ab.\nSource: Test dictionary",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(tt.pair, "test", "test")
+			require.NoError(t, err)
+
+			game.SetMaxAttempts(3)
+
+			for _, letter := range tt.guesses {
+				game.LetterGuessed(letter)
+			}
+
+			assert.Equal(t, tt.want, infrastructure.GameOverMessage(game))
+		})
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		flagValue string
+		langEnv   string
+		want      domain.Language
+	}{
+		{
+			name:      "flag value",
+			flagValue: "ru",
+			langEnv:   "en_US.UTF-8",
+			want:      domain.LanguageRussian,
+		},
+		{
+			name:      "LANG environment variable",
+			flagValue: "",
+			langEnv:   "ru_RU.UTF-8",
+			want:      domain.LanguageRussian,
+		},
+		{
+			name:      "unknown language",
+			flagValue: "xx",
+			langEnv:   "C.UTF-8",
+			want:      domain.LanguageEnglish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", "")
+			t.Setenv("LC_MESSAGES", "")
+			t.Setenv("LANG", tt.langEnv)
+
+			assert.Equal(t, tt.want, infrastructure.DetectLanguage(tt.flagValue))
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	defer infrastructure.SetLanguage(infrastructure.GetLanguage())
+
+	infrastructure.SetLanguage(domain.LanguageRussian)
+	assert.Equal(t, "Введите одну букву: ", infrastructure.Localize(infrastructure.MessageEnterLetter))
+	assert.Equal(t, "Попытки закончились. Вы проиграли! \nСлово: кот", infrastructure.Localize(infrastructure.MessageLose, "кот"))
+
+	infrastructure.SetLanguage("xx")
+	assert.Equal(t, "Enter one letter: ", infrastructure.Localize(infrastructure.MessageEnterLetter))
+}
+
+func TestFindWordPack(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     domain.Language
+		wantFile string
+		wantLang domain.Language
+	}{
+		{
+			name:     "english pack",
+			lang:     domain.LanguageEnglish,
+			wantFile: "words.json",
+			wantLang: domain.LanguageEnglish,
+		},
+		{
+			name:     "russian pack",
+			lang:     domain.LanguageRussian,
+			wantFile: "words_ru.json",
+			wantLang: domain.LanguageRussian,
+		},
+		{
+			name:     "missing pack falls back to default",
+			lang:     "de",
+			wantFile: "words.json",
+			wantLang: domain.LanguageEnglish,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := infrastructure.FindWordPack(filepath.Join("..", "..", "files"), tt.lang)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFile, filepath.Base(path))
+
+			absPath, err := filepath.Abs(path)
+			require.NoError(t, err)
+
+			provider, err := infrastructure.CreateProviderFromJSONFile(absPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLang, provider.Language)
+		})
+	}
+}
+
+func TestNewGameUI_fallsBackToLineMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		fullscreen bool
+	}{
+		{
+			name:       "line mode requested",
+			fullscreen: false,
+		},
+		{
+			name:       "full-screen mode without terminal",
+			fullscreen: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+			require.NoError(t, err)
+			defer output.Close()
+
+			input := pipeWithInput(t, "a\n")
+
+			ui := infrastructure.NewGameUI(
+				tt.fullscreen,
+				&infrastructure.BoxRenderer{},
+				infrastructure.RenderOptions{},
+				input,
+				output,
+				discardLogger,
+			)
+			defer ui.Close()
+
+			assert.IsType(t, &infrastructure.LineUI{}, ui)
+
+			playerInput, err := ui.ReadInput(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 'a', playerInput.Letter)
+		})
+	}
+}
+
+// pipeWithInput returns the read end of a pipe, which is not a terminal, holding the input.
+func pipeWithInput(t *testing.T, input string) *os.File {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	_, err = w.WriteString(input)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	t.Cleanup(func() { r.Close() })
+
+	return r
+}
+
+func TestRemainingLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     domain.Language
+		packLang domain.Language
+		word     string
+		guesses  []rune
+		want     string
+	}{
+		{
+			name:    "english alphabet",
+			lang:    domain.LanguageEnglish,
+			word:    "apple",
+			guesses: []rune{'a', 'z', 'e', 'q'},
+			want:    "bcdfghijklmnoprstuvwxy",
+		},
+		{
+			name:    "russian alphabet",
+			lang:    domain.LanguageRussian,
+			word:    "кот",
+			guesses: []rune{'к', 'я', 'а'},
+			want:    "бвгдеёжзийлмнопрстуфхцчшщъыьэю",
+		},
+		{
+			name:     "alphabet of the word pack over the language of the messages",
+			lang:     domain.LanguageEnglish,
+			packLang: domain.LanguageRussian,
+			word:     "кот",
+			guesses:  []rune{'к', 'я', 'а'},
+			want:     "бвгдеёжзийлмнопрстуфхцчшщъыьэю",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(domain.WordHintPair{Word: tt.word, Hint: "hint", Language: tt.packLang}, "test", "easy")
+			require.NoError(t, err)
+
+			for _, letter := range tt.guesses {
+				game.LetterGuessed(letter)
+			}
+
+			assert.Equal(t, tt.want, string(infrastructure.RemainingLetters(game.Snapshot(), tt.lang)))
+		})
+	}
+}
+
+var update = flag.Bool("update", false, "Rewrite the golden files in testdata with the current output")
+
+func TestRenderer_golden(t *testing.T) {
+	// The renderers draw in the language of their options, whatever the language of the messages.
+	defer infrastructure.SetLanguage(infrastructure.GetLanguage())
+
+	infrastructure.SetLanguage(domain.LanguageRussian)
+
+	tests := []struct {
+		name     string
+		renderer infrastructure.Renderer
+		word     string
+		hint     string
+		hints    []string
+		// wordLang is the language of the word pack, which may differ from the language of the renderer.
+		wordLang domain.Language
+		guesses  []rune
+		// timeLimit is far longer than the test, so the countdown shows all of it.
+		timeLimit time.Duration
+	}{
+		{
+			name:     "box_start",
+			renderer: &infrastructure.BoxRenderer{Width: 50},
+			word:     "apple",
+			hint:     "A fruit",
+		},
+		{
+			name:     "box_with_hint",
+			renderer: &infrastructure.BoxRenderer{Width: 50},
+			word:     "apple",
+			hint:     "A fruit",
+			guesses:  []rune{'a', 'x', 'y', 'z', 'q'},
+		},
+		{
+			name:     "box_narrow_long_phrase",
+			renderer: &infrastructure.BoxRenderer{Width: 30},
+			word:     "the quick brown fox jumps over the lazy dog",
+			hint:     "A sentence that uses every letter of the alphabet at least once",
+			guesses:  []rune{'o', 'x', 'z', 'v', 'w', 'q'},
+		},
+		{
+			name:     "box_wide_characters",
+			renderer: &infrastructure.BoxRenderer{Width: 40},
+			word:     "cat",
+			hint:     "猫 means cat in Japanese, 고양이 in Korean",
+			guesses:  []rune{'c', 'x', 'y', 'z', 'q'},
+		},
+		{
+			name:     "box_progressive_hints",
+			renderer: &infrastructure.BoxRenderer{Width: 50},
+			word:     "apple",
+			hint:     "A fruit",
+			hints:    []string{"Keeps the doctor away", "Starts with the first letter of the alphabet"},
+			guesses:  []rune{'x', 'y', 'z', 'q', 'w'},
+		},
+		{
+			name: "box_colored",
+			renderer: &infrastructure.BoxRenderer{Width: 50, RenderOptions: infrastructure.RenderOptions{
+				Theme: infrastructure.Theme{
+					Hit: "<hit>", Miss: "<miss>", Hint: "<hint>", Safe: "<safe>", Warning: "<warning>", Danger: "<danger>",
+				},
+			}},
+			word:    "apple",
+			hint:    "A fruit",
+			guesses: []rune{'a', 'x', 'y', 'z', 'q'},
+		},
+		{
+			name: "box_russian_art_pack",
+			renderer: &infrastructure.BoxRenderer{Width: 50, RenderOptions: infrastructure.RenderOptions{
+				Language: domain.LanguageRussian,
+				Art:      []string{"  ~~~~", "  ~~o~", "  ~\\|/~", "  ~/_\\~"},
+			}},
+			word:     "apple",
+			hint:     "A fruit",
+			wordLang: domain.LanguageEnglish,
+			guesses:  []rune{'a', 'x', 'y', 'z', 'q'},
+		},
+		{
+			name:     "plain_russian",
+			renderer: &infrastructure.PlainRenderer{Language: domain.LanguageRussian},
+			word:     "apple",
+			hint:     "A fruit",
+			wordLang: domain.LanguageEnglish,
+			guesses:  []rune{'a', 'x'},
+		},
+		{
+			name:      "box_time_left",
+			renderer:  &infrastructure.BoxRenderer{Width: 50},
+			word:      "apple",
+			hint:      "A fruit",
+			guesses:   []rune{'a', 'x'},
+			timeLimit: 90 * time.Second,
+		},
+		{
+			name:      "plain_time_left",
+			renderer:  &infrastructure.PlainRenderer{},
+			word:      "apple",
+			hint:      "A fruit",
+			guesses:   []rune{'a', 'x'},
+			timeLimit: 90 * time.Second,
+		},
+		{
+			name:     "plain_with_hint",
+			renderer: &infrastructure.PlainRenderer{},
+			word:     "apple",
+			hint:     "A fruit",
+			guesses:  []rune{'a', 'x', 'y', 'z', 'q'},
+		},
+		{
+			name:     "accessible_with_hint",
+			renderer: &infrastructure.AccessibleRenderer{},
+			word:     "apple",
+			hint:     "A fruit.",
+			guesses:  []rune{'a', 'x', 'y', 'z', 'q'},
+		},
+		{
+			name:     "accessible_phrase",
+			renderer: &infrastructure.AccessibleRenderer{},
+			word:     "ice cream",
+			hint:     "A dessert",
+			guesses:  []rune{'e', 'c'},
+		},
+		{
+			name:     "json_lost",
+			renderer: &infrastructure.JSONRenderer{},
+			word:     "apple",
+			hint:     "A fruit",
+			guesses:  []rune{'b', 'c', 'd', 'f', 'g', 'h', 'i'},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(
+				domain.WordHintPair{Word: tt.word, Hint: tt.hint, Hints: tt.hints, Language: tt.wordLang},
+				"fruits",
+				"medium",
+			)
+			require.NoError(t, err)
+
+			game.SetTimeLimit(tt.timeLimit)
+
+			for _, letter := range tt.guesses {
+				game.LetterGuessed(letter)
+			}
+
+			var output bytes.Buffer
+			require.NoError(t, tt.renderer.Render(&output, game.Snapshot()))
+
+			goldenPath := filepath.Join("testdata", tt.name+".golden")
+
+			if *update {
+				require.NoError(t, os.WriteFile(goldenPath, output.Bytes(), 0o600))
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), output.String())
+		})
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	options := infrastructure.RenderOptions{
+		Language: domain.LanguageRussian,
+		Theme:    infrastructure.Theme{Hit: "<hit>"},
+		Art:      []string{"|", "o"},
+	}
+
+	tests := []struct {
+		name string
+		want infrastructure.Renderer
+	}{
+		{name: "box", want: &infrastructure.BoxRenderer{RenderOptions: options}},
+		{name: "Plain", want: &infrastructure.PlainRenderer{Language: domain.LanguageRussian}},
+		{name: "json", want: &infrastructure.JSONRenderer{}},
+		{name: "accessible", want: &infrastructure.AccessibleRenderer{Language: domain.LanguageRussian}},
+		{name: "unknown", want: &infrastructure.BoxRenderer{RenderOptions: options}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, infrastructure.NewRenderer(tt.name, options))
+		})
+	}
+}
+
+func TestDetectTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		theme   string
+		noColor string
+	}{
+		{
+			name:    "NO_COLOR is set",
+			theme:   "default",
+			noColor: "1",
+		},
+		{
+			name:  "stdout is not a terminal",
+			theme: "contrast",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			assert.Equal(t, infrastructure.Theme{}, infrastructure.DetectTheme(tt.theme))
+		})
+	}
+}
+
+func TestLoadArtPack(t *testing.T) {
+	tests := []struct {
+		name       string
+		filePath   string
+		wantFrames int
+		wantErr    bool
+	}{
+		{
+			name:       "gallows pack",
+			filePath:   infrastructure.FindArtPack(filepath.Join("..", "..", "files"), "gallows"),
+			wantFrames: 8,
+		},
+		{
+			name:       "snowman pack",
+			filePath:   infrastructure.FindArtPack(filepath.Join("..", "..", "files"), "snowman"),
+			wantFrames: 8,
+		},
+		{
+			name:       "ship pack",
+			filePath:   infrastructure.FindArtPack(filepath.Join("..", "..", "files"), "ship"),
+			wantFrames: 6,
+		},
+		{
+			name:     "frames of different width",
+			filePath: filepath.Join("..", "..", "files", "test", "art_uneven_width_test.json"),
+			wantErr:  true,
+		},
+		{
+			name:     "frames of different height",
+			filePath: filepath.Join("..", "..", "files", "test", "art_uneven_height_test.json"),
+			wantErr:  true,
+		},
+		{
+			name:     "single frame",
+			filePath: filepath.Join("..", "..", "files", "test", "art_single_frame_test.json"),
+			wantErr:  true,
+		},
+		{
+			name:     "missing pack",
+			filePath: infrastructure.FindArtPack(filepath.Join("..", "..", "files"), "missing"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames, err := infrastructure.LoadArtPack(tt.filePath)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, frames, tt.wantFrames)
+		})
+	}
+}
+
+func TestGameRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+
+	records, err := infrastructure.LoadGameRecords(path)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	want := []domain.GameRecord{
+		{Word: "cat", Category: "animals", Difficulty: "easy", Result: domain.EventWon, Attempts: 2, MaxAttempts: 9},
+		{Word: "paris", Category: "cities", Difficulty: "hard", Result: domain.EventLost, Attempts: 5, MaxAttempts: 5},
+	}
+
+	for _, record := range want {
+		require.NoError(t, infrastructure.AppendGameRecord(path, record))
+	}
+
+	records, err = infrastructure.LoadGameRecords(path)
+	require.NoError(t, err)
+
+	for i := range records {
+		assert.True(t, want[i].FinishedAt.Equal(records[i].FinishedAt))
+		records[i].FinishedAt = want[i].FinishedAt
+	}
+
+	assert.Equal(t, want, records)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+	_, err = infrastructure.LoadGameRecords(path)
+	assert.Error(t, err)
+}
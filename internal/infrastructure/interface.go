@@ -2,48 +2,114 @@ package infrastructure
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"unicode"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
+	"golang.org/x/text/width"
+)
+
+const (
+	// defaultMenuWidth is the width of the menu, borders included, when the width of the terminal is unknown.
+	defaultMenuWidth = 50
+	// minMenuWidth keeps the gallows and the labels readable in very narrow terminals.
+	minMenuWidth = 30
+	// maxMenuWidth keeps the menu compact in very wide terminals.
+	maxMenuWidth = 80
 )
 
 func PrintGameMenu(game *domain.Game) {
+	if err := (&BoxRenderer{}).Render(os.Stdout, game.Snapshot()); err != nil {
+		slog.Error("printing game menu", slog.String("error", err.Error()))
+	}
+}
+
+// BoxRenderer draws the game as a box-drawing frame with the gallows art, in the language, theme and art
+// of its options. Width is the width of the frame including borders; zero fits the frame to the terminal.
+type BoxRenderer struct {
+	Width int
+	RenderOptions
+}
+
+func (r *BoxRenderer) Render(w io.Writer, snapshot domain.GameSnapshot) error {
 	titleCaser := cases.Title(language.Und, cases.NoLower)
-	attempts := game.GetAttempts()
-	maxAttempts := game.GetMaxAttempts()
 
-	category := titleCaser.String(string(game.GetCategory()))
-	difficulty := titleCaser.String(string(game.GetDifficulty()))
+	category := titleCaser.String(string(snapshot.Category))
+	difficulty := titleCaser.String(string(snapshot.Difficulty))
 
-	fmt.Println()
-	fmt.Println("╔════════════════════════════════════════════════╗")
-	fmt.Println("║                  Hangman Game                  ║")
-	fmt.Println("╠════════════════════════════════════════════════╣")
+	frameWidth := r.Width
+	if frameWidth == 0 {
+		frameWidth = TerminalWidth(defaultMenuWidth)
+	}
+
+	// innerWidth is the number of columns between the "║ " and " ║" borders.
+	innerWidth := min(max(frameWidth, minMenuWidth), maxMenuWidth) - 4
+	separator := "╠" + strings.Repeat("═", innerWidth+2) + "╣"
 
-	fmt.Printf("║ Attempts: %d/%-*d ║\n", attempts, 35-len(fmt.Sprintf("%d", attempts)), maxAttempts)
-	fmt.Printf("║ Difficulty: %-34s ║\n", truncateString(difficulty, 34))
+	var menu strings.Builder
 
-	fmt.Println("╠════════════════════════════════════════════════╣")
+	fmt.Fprintln(&menu)
+	fmt.Fprintln(&menu, "╔"+strings.Repeat("═", innerWidth+2)+"╗")
+	fmt.Fprintf(&menu, "║%s║\n", centerString(localizeIn(r.Language, MessageTitle), innerWidth+2))
+	fmt.Fprintln(&menu, separator)
 
-	fmt.Printf("║ Category: %-36s ║\n", truncateString(category, 36))
-	fmt.Printf("║ Word: %-40s ║\n", game.GetWordWithGuesses())
+	r.writeMenuRow(&menu, innerWidth, MessageAttempts, "", fmt.Sprintf("%d/%d", snapshot.Attempts, snapshot.MaxAttempts))
+	r.writeMenuRow(&menu, innerWidth, MessageDifficulty, "", difficulty)
 
-	if game.GetAttempts() >= game.GetMaxAttempts()/2 {
-		fmt.Println("╠════════════════════════════════════════════════╣")
-		fmt.Printf("║ Hint: %-40s ║\n", truncateString(game.GetWordAndHint().Hint, 40))
+	if snapshot.TimeLeft > 0 {
+		r.writeMenuRow(&menu, innerWidth, MessageTimeLeft, "", FormatTimeLeft(snapshot.TimeLeft))
 	}
 
-	printHangmanStage(attempts, maxAttempts)
+	fmt.Fprintln(&menu, separator)
+
+	r.writeMenuRow(&menu, innerWidth, MessageCategory, "", category)
+	r.writeMenuRow(&menu, innerWidth, MessageWord, "", snapshot.Word)
+
+	fmt.Fprintln(&menu, separator)
+
+	r.writeMenuRow(&menu, innerWidth, MessageHits, r.Theme.Hit, strings.Join(snapshot.Hits, " "))
+	r.writeMenuRow(&menu, innerWidth, MessageMisses, r.Theme.Miss, strings.Join(snapshot.Misses, " "))
+	r.writeMenuRow(&menu, innerWidth, MessageRemaining, "", string(RemainingLetters(snapshot, r.Language)))
+
+	if len(snapshot.Hints) > 0 {
+		fmt.Fprintln(&menu, separator)
+
+		for _, hint := range snapshot.Hints {
+			r.writeMenuRow(&menu, innerWidth, MessageHint, r.Theme.Hint, hint)
+		}
+	}
+
+	fmt.Fprintln(&menu, separator)
+	r.writeHangmanStage(&menu, innerWidth, snapshot.Attempts, snapshot.MaxAttempts)
+	fmt.Fprintln(&menu, "╚"+strings.Repeat("═", innerWidth+2)+"╝")
+
+	if _, err := io.WriteString(w, menu.String()); err != nil {
+		return fmt.Errorf("writing menu: %w", err)
+	}
+
+	return nil
 }
 
-// PrintHangmanStage prints the hangman stage based on the number of attempts and the maximum number of attempts.
-func printHangmanStage(attempts, maxAttempts int) {
+// writeHangmanStage writes the hangman stage based on the number of attempts and the maximum number of attempts,
+// tinted by how close the player is to losing.
+func (r *BoxRenderer) writeHangmanStage(w io.Writer, innerWidth, attempts, maxAttempts int) {
 	if maxAttempts <= 0 {
 		maxAttempts = 1
 	}
 
-	totalStages := len(hangmanStages)
+	color := r.Theme.dangerColor(attempts, maxAttempts)
+
+	stages := r.Art
+	if len(stages) == 0 {
+		stages = hangmanStages
+	}
+
+	totalStages := len(stages)
 	if totalStages == 0 {
 		return
 	}
@@ -55,80 +121,162 @@ func printHangmanStage(attempts, maxAttempts int) {
 		stageIndex = totalStages - 1
 	}
 
-	fmt.Println(hangmanStages[stageIndex])
+	// The art starts right after the left border, so the base of the gallows can touch it.
+	for _, line := range strings.Split(stages[stageIndex], "\n") {
+		fmt.Fprintf(w, "║%s%s║\n", colorize(line, color), padding(line, innerWidth+2))
+	}
+}
+
+// writeMenuRow writes a "Label: value" row of the menu in the given color, wrapping the value onto more lines
+// aligned under the first one.
+func (r *BoxRenderer) writeMenuRow(w io.Writer, innerWidth int, label MessageKey, color, value string) {
+	prefix := localizeIn(r.Language, label) + ": "
+	prefixWidth := displayWidth(prefix)
+
+	// Labels longer than half of the row would leave too little room for the value, so it moves under the label.
+	if prefixWidth > innerWidth/2 {
+		fmt.Fprintf(w, "║ %s ║\n", padString(prefix, innerWidth))
+
+		prefix, prefixWidth = "", 0
+	}
+
+	for i, line := range wrapString(value, innerWidth-prefixWidth) {
+		if i > 0 {
+			prefix = strings.Repeat(" ", prefixWidth)
+		}
+
+		fmt.Fprintf(w, "║ %s%s%s ║\n", prefix, colorize(line, color), padding(line, innerWidth-prefixWidth))
+	}
 }
 
-func truncateString(str string, maxLen int) string {
-	if len(str) > maxLen {
-		return str[:maxLen-3] + "..."
+// wrapString splits str into lines of at most width columns, breaking at spaces where possible.
+// Words wider than a line are split between lines. The result always has at least one line.
+func wrapString(str string, width int) []string {
+	var (
+		lines []string
+		line  strings.Builder
+	)
+
+	lineWidth := 0
+
+	for _, word := range strings.Fields(str) {
+		wordWidth := displayWidth(word)
+
+		if lineWidth > 0 && lineWidth+1+wordWidth > width {
+			lines = append(lines, line.String())
+			line.Reset()
+
+			lineWidth = 0
+		}
+
+		if lineWidth > 0 {
+			line.WriteByte(' ')
+			lineWidth++
+		}
+
+		for _, char := range word {
+			charWidth := runeWidth(char)
+
+			if lineWidth+charWidth > width && lineWidth > 0 {
+				lines = append(lines, line.String())
+				line.Reset()
+
+				lineWidth = 0
+			}
+
+			line.WriteRune(char)
+			lineWidth += charWidth
+		}
 	}
 
-	return str
+	return append(lines, line.String())
+}
+
+func centerString(str string, width int) string {
+	padding := max(0, width-displayWidth(str))
+	return strings.Repeat(" ", padding/2) + str + strings.Repeat(" ", padding-padding/2)
+}
+
+// padString pads str with spaces on the right to width columns.
+func padString(str string, width int) string {
+	return str + padding(str, width)
+}
+
+// padding returns the spaces that pad str to width columns; colors are applied to str alone, so they never count.
+func padding(str string, width int) string {
+	return strings.Repeat(" ", max(0, width-displayWidth(str)))
+}
+
+// displayWidth returns the number of terminal columns str takes, counting wide East Asian characters as two.
+func displayWidth(str string) int {
+	columns := 0
+
+	for _, char := range str {
+		columns += runeWidth(char)
+	}
+
+	return columns
+}
+
+func runeWidth(char rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, char) || unicode.Is(unicode.Me, char) || unicode.Is(unicode.Cf, char):
+		return 0
+	case width.LookupRune(char).Kind() == width.EastAsianWide || width.LookupRune(char).Kind() == width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
 }
 
+// hangmanStages are the frames of the built-in gallows art, drawn inside the menu frame unless an art pack is used.
 var hangmanStages = []string{
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |                                              ║
-║ |                                              ║
-║ |                                              ║
-║ |                                              ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |                                              ║
-║ |                                              ║
-║ |                                              ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |   0                                          ║
-║ |                                              ║
-║ |                                              ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |   0                                          ║
-║ |   |                                          ║
-║ |                                              ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |   0                                          ║
-║ |  /|                                          ║
-║ |                                              ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |   0                                          ║
-║ |  /|\                                         ║
-║ |                                              ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |   0                                          ║
-║ |  /|\                                         ║
-║ |  /                                           ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
-	`╠════════════════════════════════════════════════╣
-║ +---+                                          ║
-║ |   |                                          ║
-║ |   0                                          ║
-║ |  /|\                                         ║
-║ |  / \                                         ║
-║/|\                                             ║
-╚════════════════════════════════════════════════╝`,
+	` +---+
+ |
+ |
+ |
+ |
+/|\`,
+	` +---+
+ |   |
+ |
+ |
+ |
+/|\`,
+	` +---+
+ |   |
+ |   0
+ |
+ |
+/|\`,
+	` +---+
+ |   |
+ |   0
+ |   |
+ |
+/|\`,
+	` +---+
+ |   |
+ |   0
+ |  /|
+ |
+/|\`,
+	` +---+
+ |   |
+ |   0
+ |  /|\
+ |
+/|\`,
+	` +---+
+ |   |
+ |   0
+ |  /|\
+ |  /
+/|\`,
+	` +---+
+ |   |
+ |   0
+ |  /|\
+ |  / \
+/|\`,
 }
@@ -1,10 +1,13 @@
 package infrastructure
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"sync"
 )
 
 type Logger struct {
@@ -21,8 +24,13 @@ func CloseLogger(logger *Logger) error {
 	return nil
 }
 
-func InitLogger() (*Logger, error) {
-	absPath, err := filepath.Abs(filepath.Join("..", "..", "var", "logs.log"))
+// InitLogger opens the log file at filePath, var/logs.log when it is empty, and logs records from level up to it.
+func InitLogger(filePath string, level slog.Level) (*Logger, error) {
+	if filePath == "" {
+		filePath = filepath.Join("..", "..", "var", "logs.log")
+	}
+
+	absPath, err := filepath.Abs(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("getting absolute path: %w", err)
 	}
@@ -33,9 +41,64 @@ func InitLogger() (*Logger, error) {
 	}
 
 	logger := &Logger{
-		slog.New(slog.NewTextHandler(file, nil)),
+		slog.New(slog.NewTextHandler(file, &slog.HandlerOptions{Level: level})),
 		file,
 	}
 
 	return logger, nil
 }
+
+// BufferLogs keeps the records logged from now on in memory until the returned function writes them to the logger.
+// The log file depends on the flags, so it is only opened after they are parsed, while parsing already logs.
+func BufferLogs() (flush func(logger *slog.Logger)) {
+	buffer := &logBuffer{}
+	slog.SetDefault(slog.New(&bufferHandler{buffer: buffer}))
+
+	return func(logger *slog.Logger) {
+		buffer.mu.Lock()
+		defer buffer.mu.Unlock()
+
+		for _, record := range buffer.records {
+			if logger.Enabled(context.Background(), record.Level) {
+				_ = logger.Handler().Handle(context.Background(), record)
+			}
+		}
+
+		buffer.records = nil
+	}
+}
+
+type logBuffer struct {
+	mu      sync.Mutex
+	records []slog.Record
+}
+
+// bufferHandler adds the records to a logBuffer. Groups are not used by the program, so they are ignored.
+type bufferHandler struct {
+	buffer *logBuffer
+	attrs  []slog.Attr
+}
+
+func (h *bufferHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *bufferHandler) Handle(_ context.Context, record slog.Record) error {
+	record = record.Clone()
+	record.AddAttrs(h.attrs...)
+
+	h.buffer.mu.Lock()
+	defer h.buffer.mu.Unlock()
+
+	h.buffer.records = append(h.buffer.records, record)
+
+	return nil
+}
+
+func (h *bufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &bufferHandler{buffer: h.buffer, attrs: append(slices.Clip(h.attrs), attrs...)}
+}
+
+func (h *bufferHandler) WithGroup(string) slog.Handler {
+	return h
+}
@@ -0,0 +1,427 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+type MessageKey string
+
+const (
+	MessageTitle            MessageKey = "title"
+	MessageAttempts         MessageKey = "attempts"
+	MessageDifficulty       MessageKey = "difficulty"
+	MessageCategory         MessageKey = "category"
+	MessageWord             MessageKey = "word"
+	MessageHint             MessageKey = "hint"
+	MessageEnterLetter      MessageKey = "enter_letter"
+	MessageWrongInput       MessageKey = "wrong_input"
+	MessageAlreadyGuessed   MessageKey = "already_guessed"
+	MessageLetterGuessed    MessageKey = "letter_guessed"
+	MessageLetterNotInWord  MessageKey = "letter_not_in_word"
+	MessageWin              MessageKey = "win"
+	MessageLose             MessageKey = "lose"
+	MessageAbandoned        MessageKey = "abandoned"
+	MessageTimeUp           MessageKey = "time_up"
+	MessageTurnTimedOut     MessageKey = "turn_timed_out"
+	MessageTimeLeft         MessageKey = "time_left"
+	MessageBlitzNext        MessageKey = "blitz_next"
+	MessageBlitzNoWords     MessageKey = "blitz_no_words"
+	MessageBlitzScore       MessageKey = "blitz_score"
+	MessageRandomDifficulty MessageKey = "random_difficulty"
+	MessageRandomCategory   MessageKey = "random_category"
+	MessageDidYouMean       MessageKey = "did_you_mean"
+	MessageInitError        MessageKey = "init_error"
+	MessageJoinError        MessageKey = "join_error"
+	MessageHostError        MessageKey = "host_error"
+	MessageServeError       MessageKey = "serve_error"
+	MessageArtError         MessageKey = "art_error"
+	MessageWordPackError    MessageKey = "word_pack_error"
+	MessageStatsError       MessageKey = "stats_error"
+	MessageConfigError      MessageKey = "config_error"
+	MessageRoomHosted       MessageKey = "room_hosted"
+	MessageLiveServed       MessageKey = "live_served"
+	MessageEnterName        MessageKey = "enter_name"
+	MessagePlayerJoined     MessageKey = "player_joined"
+	MessagePlayerLeft       MessageKey = "player_left"
+	MessagePlayerGuessed    MessageKey = "player_guessed"
+	MessageNotYourTurn      MessageKey = "not_your_turn"
+	MessageGameAlreadyOver  MessageKey = "game_already_over"
+	MessageNotInRoom        MessageKey = "not_in_room"
+	MessageSpectator        MessageKey = "spectator"
+	MessageTurn             MessageKey = "turn"
+	MessageScoreboard       MessageKey = "scoreboard"
+	MessageScore            MessageKey = "score"
+	MessageCompletedBy      MessageKey = "completed_by"
+	MessageHits             MessageKey = "hits"
+	MessageMisses           MessageKey = "misses"
+	MessageRemaining        MessageKey = "remaining"
+	MessageHintCommand      MessageKey = "hint_command"
+	MessageHintUnlocked     MessageKey = "hint_unlocked"
+	MessageLetterRevealed   MessageKey = "letter_revealed"
+	MessageHintDenied       MessageKey = "hint_denied"
+	MessageDefinition       MessageKey = "definition"
+	MessageExample          MessageKey = "example"
+	MessageSource           MessageKey = "source"
+	MessageSpokenGame       MessageKey = "spoken_game"
+	MessageSpokenWord       MessageKey = "spoken_word"
+	MessageSpokenBlank      MessageKey = "spoken_blank"
+	MessageSpokenSpace      MessageKey = "spoken_space"
+	MessageSpokenAttempts   MessageKey = "spoken_attempts"
+	MessageSpokenHits       MessageKey = "spoken_hits"
+	MessageSpokenNoHits     MessageKey = "spoken_no_hits"
+	MessageSpokenMisses     MessageKey = "spoken_misses"
+	MessageSpokenNoMisses   MessageKey = "spoken_no_misses"
+	MessageSpokenRemaining  MessageKey = "spoken_remaining"
+	MessageSpokenHint       MessageKey = "spoken_hint"
+	MessageSpokenTimeLeft   MessageKey = "spoken_time_left"
+	MessageListCategory     MessageKey = "list_category"
+	MessageListDifficulty   MessageKey = "list_difficulty"
+	MessageNoDifficulty     MessageKey = "no_difficulty"
+	MessageValidPack        MessageKey = "valid_pack"
+	MessageInvalidPack      MessageKey = "invalid_pack"
+	MessageNoStats          MessageKey = "no_stats"
+	MessageStatsPlayed      MessageKey = "stats_played"
+	MessageStatsWon         MessageKey = "stats_won"
+	MessageStatsLost        MessageKey = "stats_lost"
+	MessageStatsStreak      MessageKey = "stats_streak"
+	MessageStatsDifficulty  MessageKey = "stats_difficulty"
+)
+
+// alphabets are the letters the words of each language are made of.
+var alphabets = map[domain.Language]string{
+	domain.LanguageEnglish: "abcdefghijklmnopqrstuvwxyz",
+	domain.LanguageRussian: "абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+}
+
+var catalog = map[domain.Language]map[MessageKey]string{
+	domain.LanguageEnglish: {
+		MessageTitle:            "Hangman Game",
+		MessageAttempts:         "Attempts",
+		MessageDifficulty:       "Difficulty",
+		MessageCategory:         "Category",
+		MessageWord:             "Word",
+		MessageHint:             "Hint",
+		MessageEnterLetter:      "Enter one letter: ",
+		MessageWrongInput:       "Wrong input. Please enter one letter.",
+		MessageAlreadyGuessed:   "Letter already guessed",
+		MessageLetterGuessed:    "Letter guessed",
+		MessageLetterNotInWord:  "Letter not in word",
+		MessageWin:              "Word guessed. You win!",
+		MessageLose:             "Max attempts reached. You lose! \nWord: %s",
+		MessageAbandoned:        "Game stopped. \nWord: %s",
+		MessageTimeUp:           "Time is up. You lose! \nWord: %s",
+		MessageTurnTimedOut:     "Time for the guess is up, an attempt is lost",
+		MessageTimeLeft:         "Time left",
+		MessageBlitzNext:        "Next word! Attempts left: %d",
+		MessageBlitzNoWords:     "The word pack has no more words.",
+		MessageBlitzScore:       "Words solved: %d of %d",
+		MessageRandomDifficulty: "Difficulty '%s' not found in the word pack.%s A random difficulty is used.",
+		MessageRandomCategory:   "Category '%s' not found in the word pack.%s A random category is used.",
+		MessageDidYouMean:       " Did you mean %s?",
+		MessageInitError:        "Error while initializing game. \nError: ",
+		MessageJoinError:        "Error while joining room. \nError: ",
+		MessageHostError:        "Error while hosting room. \nError: ",
+		MessageServeError:       "Error while serving room. \nError: ",
+		MessageArtError:         "Error while loading art pack. \nError: ",
+		MessageWordPackError:    "Error while loading word pack. \nError: ",
+		MessageStatsError:       "Error while reading statistics. \nError: ",
+		MessageConfigError:      "Error in the config file or environment variables. \nError: ",
+		MessageRoomHosted:       "Room is hosted on %s. Waiting for players...\n",
+		MessageLiveServed:       "Live game is served on http://%s\n",
+		MessageEnterName:        "Enter your name:",
+		MessagePlayerJoined:     "%s joined the room (%s mode)",
+		MessagePlayerLeft:       "%s left the room",
+		MessagePlayerGuessed:    "%s guessed '%c': %s",
+		MessageNotYourTurn:      "Not your turn, waiting for %s",
+		MessageGameAlreadyOver:  "Game is already over",
+		MessageNotInRoom:        "Player is not in the room",
+		MessageSpectator:        "Spectators can not guess letters",
+		MessageTurn:             "Turn",
+		MessageScoreboard:       "Scoreboard:",
+		MessageScore:            "  %s: %d correct",
+		MessageCompletedBy:      "Word completed by %s",
+		MessageHits:             "Hits",
+		MessageMisses:           "Misses",
+		MessageRemaining:        "Remaining",
+		MessageHintCommand:      "Type :hint to get help at the cost of one attempt.",
+		MessageHintUnlocked:     "Hint unlocked at the cost of one attempt",
+		MessageLetterRevealed:   "Letter '%c' revealed at the cost of one attempt",
+		MessageHintDenied:       "Help is not available now",
+		MessageDefinition:       "Definition",
+		MessageExample:          "Example",
+		MessageSource:           "Source",
+		MessageSpokenGame:       "Category %s, difficulty %s.",
+		MessageSpokenWord:       "Word has %d letters: %s.",
+		MessageSpokenBlank:      "blank",
+		MessageSpokenSpace:      "space",
+		MessageSpokenAttempts:   "%d of %d attempts used.",
+		MessageSpokenHits:       "Letters in the word: %s.",
+		MessageSpokenNoHits:     "No letters guessed yet.",
+		MessageSpokenMisses:     "Letters not in the word: %s.",
+		MessageSpokenNoMisses:   "No misses yet.",
+		MessageSpokenRemaining:  "%d letters not tried yet.",
+		MessageSpokenHint:       "Hint: %s.",
+		MessageSpokenTimeLeft:   "%d seconds left.",
+		MessageListCategory:     "%s - %d words",
+		MessageListDifficulty:   "%s - %d attempts, %d words",
+		MessageNoDifficulty:     "Difficulty '%s' not found in the word pack.%s",
+		MessageValidPack:        "%s: OK, %d difficulties, %d categories, %d words",
+		MessageInvalidPack:      "%s: invalid. \nError: ",
+		MessageNoStats:          "No games played yet.",
+		MessageStatsPlayed:      "Games played: %d",
+		MessageStatsWon:         "Won: %d (%d%%)",
+		MessageStatsLost:        "Lost: %d",
+		MessageStatsStreak:      "Current streak: %d, best streak: %d",
+		MessageStatsDifficulty:  "  %s: %d played, %d won",
+	},
+	domain.LanguageRussian: {
+		MessageTitle:            "Виселица",
+		MessageAttempts:         "Попытки",
+		MessageDifficulty:       "Сложность",
+		MessageCategory:         "Категория",
+		MessageWord:             "Слово",
+		MessageHint:             "Подсказка",
+		MessageEnterLetter:      "Введите одну букву: ",
+		MessageWrongInput:       "Неверный ввод. Пожалуйста, введите одну букву.",
+		MessageAlreadyGuessed:   "Эта буква уже была",
+		MessageLetterGuessed:    "Буква угадана",
+		MessageLetterNotInWord:  "Такой буквы нет в слове",
+		MessageWin:              "Слово угадано. Вы победили!",
+		MessageLose:             "Попытки закончились. Вы проиграли! \nСлово: %s",
+		MessageAbandoned:        "Игра прервана. \nСлово: %s",
+		MessageTimeUp:           "Время вышло. Вы проиграли! \nСлово: %s",
+		MessageTurnTimedOut:     "Время на ход вышло, попытка потеряна",
+		MessageTimeLeft:         "Осталось времени",
+		MessageBlitzNext:        "Следующее слово! Осталось попыток: %d",
+		MessageBlitzNoWords:     "В наборе больше не осталось слов.",
+		MessageBlitzScore:       "Угадано слов: %d из %d",
+		MessageRandomDifficulty: "Сложность '%s' не найдена в наборе слов.%s Выбрана случайная сложность.",
+		MessageRandomCategory:   "Категория '%s' не найдена в наборе слов.%s Выбрана случайная категория.",
+		MessageDidYouMean:       " Возможно, вы имели в виду %s?",
+		MessageInitError:        "Ошибка при создании игры. \nОшибка: ",
+		MessageJoinError:        "Ошибка при подключении к комнате. \nОшибка: ",
+		MessageHostError:        "Ошибка при создании комнаты. \nОшибка: ",
+		MessageServeError:       "Ошибка при запуске сервера. \nОшибка: ",
+		MessageArtError:         "Ошибка при загрузке набора рисунков. \nОшибка: ",
+		MessageWordPackError:    "Ошибка при загрузке набора слов. \nОшибка: ",
+		MessageStatsError:       "Ошибка при чтении статистики. \nОшибка: ",
+		MessageConfigError:      "Ошибка в файле настроек или переменных окружения. \nОшибка: ",
+		MessageRoomHosted:       "Комната создана на %s. Ожидание игроков...\n",
+		MessageLiveServed:       "Игра доступна по адресу http://%s\n",
+		MessageEnterName:        "Введите ваше имя:",
+		MessagePlayerJoined:     "%s присоединился к комнате (режим %s)",
+		MessagePlayerLeft:       "%s покинул комнату",
+		MessagePlayerGuessed:    "%s назвал '%c': %s",
+		MessageNotYourTurn:      "Сейчас не ваш ход, ходит %s",
+		MessageGameAlreadyOver:  "Игра уже закончена",
+		MessageNotInRoom:        "Игрока нет в комнате",
+		MessageSpectator:        "Зрители не могут называть буквы",
+		MessageTurn:             "Ход",
+		MessageScoreboard:       "Счёт:",
+		MessageScore:            "  %s: угадано %d",
+		MessageCompletedBy:      "Слово отгадал %s",
+		MessageHits:             "Угаданы",
+		MessageMisses:           "Промахи",
+		MessageRemaining:        "Осталось",
+		MessageHintCommand:      "Введите :hint, чтобы получить помощь ценой одной попытки.",
+		MessageHintUnlocked:     "Подсказка открыта ценой одной попытки",
+		MessageLetterRevealed:   "Буква '%c' открыта ценой одной попытки",
+		MessageHintDenied:       "Помощь сейчас недоступна",
+		MessageDefinition:       "Значение",
+		MessageExample:          "Пример",
+		MessageSource:           "Источник",
+		MessageSpokenGame:       "Категория %s, сложность %s.",
+		MessageSpokenWord:       "Букв в слове: %d — %s.",
+		MessageSpokenBlank:      "пусто",
+		MessageSpokenSpace:      "пробел",
+		MessageSpokenAttempts:   "Использовано попыток: %d из %d.",
+		MessageSpokenHits:       "Угаданные буквы: %s.",
+		MessageSpokenNoHits:     "Угаданных букв пока нет.",
+		MessageSpokenMisses:     "Буквы, которых нет в слове: %s.",
+		MessageSpokenNoMisses:   "Промахов пока нет.",
+		MessageSpokenRemaining:  "Ещё не названо букв: %d.",
+		MessageSpokenHint:       "Подсказка: %s.",
+		MessageSpokenTimeLeft:   "Осталось секунд: %d.",
+		MessageListCategory:     "%s - слов: %d",
+		MessageListDifficulty:   "%s - попыток: %d, слов: %d",
+		MessageNoDifficulty:     "Сложность '%s' не найдена в наборе слов.%s",
+		MessageValidPack:        "%s: OK, сложностей: %d, категорий: %d, слов: %d",
+		MessageInvalidPack:      "%s: ошибка формата. \nОшибка: ",
+		MessageNoStats:          "Сыгранных игр пока нет.",
+		MessageStatsPlayed:      "Сыграно игр: %d",
+		MessageStatsWon:         "Побед: %d (%d%%)",
+		MessageStatsLost:        "Поражений: %d",
+		MessageStatsStreak:      "Текущая серия побед: %d, лучшая: %d",
+		MessageStatsDifficulty:  "  %s: сыграно %d, побед %d",
+	},
+}
+
+var currentLanguage = domain.LanguageEnglish
+
+func AvailableLanguages() []domain.Language {
+	languages := make([]domain.Language, 0, len(catalog))
+	for lang := range catalog {
+		languages = append(languages, lang)
+	}
+
+	slices.Sort(languages)
+
+	return languages
+}
+
+// DetectLanguage returns the language from the flag value, falling back to the LC_ALL, LC_MESSAGES and LANG
+// environment variables and then to English.
+func DetectLanguage(flagValue string) domain.Language {
+	candidates := []string{flagValue, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+
+	for _, candidate := range candidates {
+		// Locale values look like "ru_RU.UTF-8", only the language part is needed.
+		lang := domain.Language(strings.ToLower(strings.SplitN(candidate, "_", 2)[0]))
+
+		if _, exists := catalog[lang]; exists {
+			return lang
+		}
+	}
+
+	if flagValue != "" {
+		slog.Info("Language not found in list of available values, so the default value is set - en", slog.String("lang", flagValue))
+	}
+
+	return domain.LanguageEnglish
+}
+
+func SetLanguage(lang domain.Language) {
+	if _, exists := catalog[lang]; !exists {
+		slog.Info("Language not found in list of available values, so the default value is set - en", slog.String("lang", string(lang)))
+
+		lang = domain.LanguageEnglish
+	}
+
+	currentLanguage = lang
+}
+
+func GetLanguage() domain.Language {
+	return currentLanguage
+}
+
+// RemainingLetters returns the letters of the alphabet of the word that were not guessed yet; the alphabet of lang,
+// the language of the messages, is used when the word pack does not tell the language of the word.
+func RemainingLetters(snapshot domain.GameSnapshot, lang domain.Language) []rune {
+	alphabet, exists := alphabets[wordLanguage(snapshot, lang)]
+	if !exists {
+		alphabet = alphabets[domain.LanguageEnglish]
+	}
+
+	remaining := make([]rune, 0, len(alphabet))
+
+	for _, letter := range alphabet {
+		if !slices.Contains(snapshot.Guesses, string(letter)) {
+			remaining = append(remaining, letter)
+		}
+	}
+
+	return remaining
+}
+
+// wordLanguage returns the language of the word, which may differ from lang, the language of the messages,
+// falling back to the latter when the word pack does not tell it.
+func wordLanguage(snapshot domain.GameSnapshot, lang domain.Language) domain.Language {
+	if snapshot.Language != "" {
+		return snapshot.Language
+	}
+
+	return lang
+}
+
+// Localize returns the message in the current language formatted with args, falling back to English.
+func Localize(key MessageKey, args ...any) string {
+	return localizeIn(currentLanguage, key, args...)
+}
+
+// localizeIn returns the message in lang formatted with args, falling back to English.
+func localizeIn(lang domain.Language, key MessageKey, args ...any) string {
+	message, exists := catalog[lang][key]
+	if !exists {
+		message = catalog[domain.LanguageEnglish][key]
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// GuessMessage describes the outcome of a guess to the player.
+func GuessMessage(result domain.GuessResult) string {
+	switch result.Outcome {
+	case domain.EventAlreadyGuessed:
+		return Localize(MessageAlreadyGuessed)
+	case domain.EventMiss:
+		return Localize(MessageLetterNotInWord)
+	case domain.EventHintUnlocked:
+		return Localize(MessageHintUnlocked)
+	case domain.EventLetterRevealed:
+		return Localize(MessageLetterRevealed, result.Letter)
+	case domain.EventHintDenied:
+		return Localize(MessageHintDenied)
+	case domain.EventTurnTimedOut:
+		return Localize(MessageTurnTimedOut)
+	default:
+		return Localize(MessageLetterGuessed)
+	}
+}
+
+// GameOverMessage describes the end of the game to the player, followed by the details of the word the pack gives.
+func GameOverMessage(game *domain.Game) string {
+	message := Localize(MessageLose, game.GetWordAndHint().Word)
+
+	if _, result := game.GameIsOver(); result == domain.EventWon {
+		message = Localize(MessageWin)
+	} else if game.IsTimeUp() {
+		message = Localize(MessageTimeUp, game.GetWordAndHint().Word)
+	}
+
+	return message + wordDetails(game.GetWordAndHint())
+}
+
+// FormatTimeLeft returns the seconds left as minutes and seconds, e.g. 1:05.
+func FormatTimeLeft(seconds int) string {
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// AbandonedMessage reveals the word of a game stopped before it was over.
+func AbandonedMessage(game *domain.Game) string {
+	return Localize(MessageAbandoned, game.GetWordAndHint().Word) + wordDetails(game.GetWordAndHint())
+}
+
+// wordDetails lists the definition, example and source of the word, one per line, each starting with a line break.
+func wordDetails(pair domain.WordHintPair) string {
+	var details strings.Builder
+
+	if pair.Definition != "" {
+		details.WriteString("\n")
+
+		if pair.Emoji != "" {
+			details.WriteString(pair.Emoji + " ")
+		}
+
+		details.WriteString(Localize(MessageDefinition) + ": " + pair.Definition)
+	}
+
+	if pair.Example != "" {
+		details.WriteString("\n" + Localize(MessageExample) + ": " + pair.Example)
+	}
+
+	if pair.Source != "" {
+		details.WriteString("\n" + Localize(MessageSource) + ": " + pair.Source)
+	}
+
+	return details.String()
+}
@@ -0,0 +1,224 @@
+package infrastructure
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const outgoingBufferSize = 64
+
+// RoomHandler is the authoritative side of a multiplayer room that network connections talk to.
+type RoomHandler interface {
+	Join(name string, send func(message string)) (int, error)
+	Guess(playerID int, letter rune) error
+	Leave(playerID int)
+	Done() <-chan struct{}
+}
+
+// HostRoom accepts players on addr until the game in the room is over.
+func HostRoom(addr string, room RoomHandler) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		slog.Error("listening for players", slog.String("addr", addr), slog.String("error", err.Error()))
+		return fmt.Errorf("listening on %s: %w", addr, err)
+	}
+
+	fmt.Print(Localize(MessageRoomHosted, listener.Addr()))
+	slog.Info("Room hosted", slog.String("addr", listener.Addr().String()))
+
+	return AcceptPlayers(listener, room)
+}
+
+// AcceptPlayers lets the players connecting to listener play in the room until the game in it is over,
+// which closes the listener.
+func AcceptPlayers(listener net.Listener, room RoomHandler) error {
+	go func() {
+		<-room.Done()
+		listener.Close()
+	}()
+
+	var connections sync.WaitGroup
+
+	defer connections.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-room.Done():
+				return nil
+			default:
+				slog.Error("accepting player connection", slog.String("error", err.Error()))
+				return fmt.Errorf("accepting connection: %w", err)
+			}
+		}
+
+		connections.Add(1)
+
+		go func() {
+			defer connections.Done()
+			handleRoomConnection(conn, room)
+		}()
+	}
+}
+
+func handleRoomConnection(conn net.Conn, room RoomHandler) {
+	defer conn.Close()
+
+	slog.Info("Player connected", slog.String("remote", conn.RemoteAddr().String()))
+
+	outgoing := newOutbox(conn.RemoteAddr().String(), func(message string) error {
+		_, err := fmt.Fprintln(conn, message)
+		return err
+	})
+	defer outgoing.close()
+
+	reader := bufio.NewReader(conn)
+
+	fmt.Fprintln(conn, Localize(MessageEnterName))
+
+	name, err := reader.ReadString('\n')
+	if err != nil {
+		slog.Error("reading player name", slog.String("error", err.Error()))
+		return
+	}
+
+	playerID, err := room.Join(name, outgoing.send)
+	if err != nil {
+		fmt.Fprintln(conn, err)
+		return
+	}
+
+	defer room.Leave(playerID)
+
+	defer interruptReadsWhenDone(room.Done(), conn)()
+
+	readGuesses(reader, playerID, room, outgoing.send)
+}
+
+func readGuesses(reader *bufio.Reader, playerID int, room RoomHandler, send func(message string)) {
+	for {
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, os.ErrDeadlineExceeded) {
+				slog.Error("reading player input", slog.String("error", err.Error()))
+			}
+
+			return
+		}
+
+		letter, ok := parseLetter(strings.TrimSpace(input))
+		if !ok {
+			send(Localize(MessageWrongInput))
+			continue
+		}
+
+		if err := room.Guess(playerID, letter); err != nil {
+			send(err.Error())
+		}
+	}
+}
+
+// interruptReadsWhenDone unblocks pending reads on conn once done is closed. The returned function stops waiting.
+func interruptReadsWhenDone(done <-chan struct{}, conn net.Conn) (stop func()) {
+	finished := make(chan struct{})
+
+	go func() {
+		select {
+		case <-done:
+			_ = conn.SetReadDeadline(time.Now())
+		case <-finished:
+		}
+	}()
+
+	return func() {
+		close(finished)
+	}
+}
+
+// outbox queues messages for a connection on a separate goroutine.
+// The room sends while holding its lock, so a slow client must never block it.
+type outbox[T any] struct {
+	messages chan T
+	done     chan struct{}
+	remote   string
+}
+
+func newOutbox[T any](remote string, write func(message T) error) *outbox[T] {
+	box := &outbox[T]{
+		messages: make(chan T, outgoingBufferSize),
+		done:     make(chan struct{}),
+		remote:   remote,
+	}
+
+	go func() {
+		defer close(box.done)
+
+		for message := range box.messages {
+			if err := write(message); err != nil {
+				slog.Error("writing to player", slog.String("remote", remote), slog.String("error", err.Error()))
+			}
+		}
+	}()
+
+	return box
+}
+
+func (box *outbox[T]) send(message T) {
+	select {
+	case box.messages <- message:
+	default:
+		slog.Warn("dropping message for slow player", slog.String("remote", box.remote))
+	}
+}
+
+// close flushes the queued messages; send must not be called afterwards.
+func (box *outbox[T]) close() {
+	close(box.messages)
+	<-box.done
+}
+
+// JoinRoom connects to a hosted room and relays the input of the player to it and its messages to output
+// until the host closes the connection or ctx is done.
+func JoinRoom(ctx context.Context, addr string, input io.Reader, output io.Writer) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		slog.Error("connecting to room", slog.String("addr", addr), slog.String("error", err.Error()))
+		return fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+
+	defer conn.Close()
+
+	slog.Info("Joined room", slog.String("addr", addr))
+
+	go func() {
+		_, _ = io.Copy(conn, input)
+	}()
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	if _, err := io.Copy(output, conn); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		slog.Error("reading from room", slog.String("error", err.Error()))
+		return fmt.Errorf("reading from room: %w", err)
+	}
+
+	return nil
+}
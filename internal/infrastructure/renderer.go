@@ -0,0 +1,170 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// Renderer draws a snapshot of the game to w, which may be a terminal, a network connection or a buffer.
+type Renderer interface {
+	Render(w io.Writer, snapshot domain.GameSnapshot) error
+}
+
+// RenderOptions are what the game is drawn with besides its snapshot. The zero value draws in English,
+// without colors and with the built-in gallows.
+type RenderOptions struct {
+	Language domain.Language
+	Theme    Theme
+	// Art are the frames of the gallows, from the empty gallows to the hanged man; empty for the built-in ones.
+	Art []string
+}
+
+// renderers are the renderers available by name.
+var renderers = map[string]func(options RenderOptions) Renderer{
+	"box":   func(options RenderOptions) Renderer { return &BoxRenderer{RenderOptions: options} },
+	"plain": func(options RenderOptions) Renderer { return &PlainRenderer{Language: options.Language} },
+	"json":  func(RenderOptions) Renderer { return &JSONRenderer{} },
+	// accessible is also selected by the --accessible flag.
+	"accessible": func(options RenderOptions) Renderer { return &AccessibleRenderer{Language: options.Language} },
+}
+
+// RendererNames returns the names NewRenderer knows, sorted.
+func RendererNames() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// NewRenderer returns the renderer with the given name drawing with the options, falling back to the box renderer.
+func NewRenderer(name string, options RenderOptions) Renderer {
+	newRenderer, exists := renderers[strings.ToLower(name)]
+	if !exists {
+		slog.Info("Renderer not found in list of available values, so the default value is set - box", slog.String("renderer", name))
+		return &BoxRenderer{RenderOptions: options}
+	}
+
+	return newRenderer(options)
+}
+
+// PlainRenderer draws the game as plain "Label: value" lines in Language without frames and art.
+type PlainRenderer struct {
+	Language domain.Language
+}
+
+func (r *PlainRenderer) Render(w io.Writer, snapshot domain.GameSnapshot) error {
+	var lines strings.Builder
+
+	writePlainLine(&lines, r.Language, MessageAttempts, fmt.Sprintf("%d/%d", snapshot.Attempts, snapshot.MaxAttempts))
+
+	if snapshot.TimeLeft > 0 {
+		writePlainLine(&lines, r.Language, MessageTimeLeft, FormatTimeLeft(snapshot.TimeLeft))
+	}
+
+	writePlainLine(&lines, r.Language, MessageDifficulty, string(snapshot.Difficulty))
+	writePlainLine(&lines, r.Language, MessageCategory, string(snapshot.Category))
+	writePlainLine(&lines, r.Language, MessageWord, snapshot.Word)
+	writePlainLine(&lines, r.Language, MessageHits, strings.Join(snapshot.Hits, " "))
+	writePlainLine(&lines, r.Language, MessageMisses, strings.Join(snapshot.Misses, " "))
+	writePlainLine(&lines, r.Language, MessageRemaining, string(RemainingLetters(snapshot, r.Language)))
+
+	for _, hint := range snapshot.Hints {
+		writePlainLine(&lines, r.Language, MessageHint, hint)
+	}
+
+	if _, err := io.WriteString(w, lines.String()); err != nil {
+		return fmt.Errorf("writing plain text: %w", err)
+	}
+
+	return nil
+}
+
+func writePlainLine(w io.Writer, lang domain.Language, label MessageKey, value string) {
+	fmt.Fprintf(w, "%s: %s\n", localizeIn(lang, label), value)
+}
+
+// AccessibleRenderer describes the game in plain sentences in Language for screen readers, one sentence per line,
+// spelling out every position of the word instead of drawing frames and art.
+type AccessibleRenderer struct {
+	Language domain.Language
+}
+
+func (r *AccessibleRenderer) Render(w io.Writer, snapshot domain.GameSnapshot) error {
+	var lines strings.Builder
+
+	localize := func(key MessageKey, args ...any) string {
+		return localizeIn(r.Language, key, args...)
+	}
+
+	fmt.Fprintln(&lines, localize(MessageSpokenGame, snapshot.Category, snapshot.Difficulty))
+
+	positions := make([]string, 0, utf8.RuneCountInString(snapshot.Word))
+	letters := 0
+
+	for _, char := range snapshot.Word {
+		switch {
+		case char == '_':
+			positions = append(positions, localize(MessageSpokenBlank))
+			letters++
+		case unicode.IsSpace(char):
+			positions = append(positions, localize(MessageSpokenSpace))
+		default:
+			positions = append(positions, string(char))
+			letters++
+		}
+	}
+
+	fmt.Fprintln(&lines, localize(MessageSpokenWord, letters, strings.Join(positions, ", ")))
+	fmt.Fprintln(&lines, localize(MessageSpokenAttempts, snapshot.Attempts, snapshot.MaxAttempts))
+
+	if snapshot.TimeLeft > 0 {
+		fmt.Fprintln(&lines, localize(MessageSpokenTimeLeft, snapshot.TimeLeft))
+	}
+
+	if len(snapshot.Hits) > 0 {
+		fmt.Fprintln(&lines, localize(MessageSpokenHits, strings.Join(snapshot.Hits, ", ")))
+	} else {
+		fmt.Fprintln(&lines, localize(MessageSpokenNoHits))
+	}
+
+	if len(snapshot.Misses) > 0 {
+		fmt.Fprintln(&lines, localize(MessageSpokenMisses, strings.Join(snapshot.Misses, ", ")))
+	} else {
+		fmt.Fprintln(&lines, localize(MessageSpokenNoMisses))
+	}
+
+	fmt.Fprintln(&lines, localize(MessageSpokenRemaining, utf8.RuneCountInString(string(RemainingLetters(snapshot, r.Language)))))
+
+	for _, hint := range snapshot.Hints {
+		fmt.Fprintln(&lines, localize(MessageSpokenHint, strings.TrimRight(hint, ".!?")))
+	}
+
+	if _, err := io.WriteString(w, lines.String()); err != nil {
+		return fmt.Errorf("writing sentences: %w", err)
+	}
+
+	return nil
+}
+
+// JSONRenderer draws the game as one JSON object per line.
+type JSONRenderer struct{}
+
+func (r *JSONRenderer) Render(w io.Writer, snapshot domain.GameSnapshot) error {
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+
+	return nil
+}
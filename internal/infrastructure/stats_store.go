@@ -0,0 +1,89 @@
+package infrastructure
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+)
+
+// AppendGameRecord adds the record as a JSON line to the statistics file at path, creating the file if needed.
+func AppendGameRecord(path string, record domain.GameRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		slog.Error("marshalling game record", slog.String("error", err.Error()))
+		return fmt.Errorf("marshalling game record: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o666)
+	if err != nil {
+		slog.Error("opening statistics file", slog.String("filePath", path), slog.String("error", err.Error()))
+		return fmt.Errorf("opening file: %w", err)
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		slog.Error("writing statistics file", slog.String("filePath", path), slog.String("error", err.Error()))
+
+		return fmt.Errorf("writing file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadGameRecords reads the records of the statistics file at path in the order they were added.
+// A missing file has no records yet.
+func LoadGameRecords(path string) ([]domain.GameRecord, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		slog.Error("opening statistics file", slog.String("filePath", path), slog.String("error", err.Error()))
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+
+	defer file.Close()
+
+	var records []domain.GameRecord
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record domain.GameRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			slog.Error("unmarshalling game record", slog.String("filePath", path), slog.Int("line", line), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("unmarshalling line %d: %w", line, err)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return records, nil
+}
+
+// StatsFile records finished games in the statistics file at Path.
+type StatsFile struct {
+	Path string
+}
+
+func (file StatsFile) RecordGame(record domain.GameRecord) error {
+	return AppendGameRecord(file.Path, record)
+}
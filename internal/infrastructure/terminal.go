@@ -0,0 +1,379 @@
+package infrastructure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"golang.org/x/term"
+)
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	hideCursor  = "\x1b[?25l"
+	showCursor  = "\x1b[?25h"
+
+	keyEscape    = '\x1b'
+	keyInterrupt = '\x03'
+	keyEndOfFile = '\x04'
+	keyEnter     = '\r'
+	keyBackspace = '\x7f'
+	keyCommand   = ':'
+)
+
+var ErrInterrupted = errors.New("interrupted by user")
+
+// keyboardLayouts are the rows of the on-screen keyboard for each language, holding every letter of its alphabet.
+var keyboardLayouts = map[domain.Language][]string{
+	domain.LanguageEnglish: {"qwertyuiop", "asdfghjkl", "zxcvbnm"},
+	domain.LanguageRussian: {"ёйцукенгшщзхъ", "фывапролджэ", "ячсмитьбю"},
+}
+
+// GameUI draws the game for the player and reads their guesses and commands.
+type GameUI interface {
+	ShowGame(game *domain.Game)
+	ShowMessage(message string)
+	// ReadInput waits for the player until ctx is done, in which case it returns the error of ctx.
+	ReadInput(ctx context.Context) (PlayerInput, error)
+	Close()
+}
+
+// NewGameUI returns the full-screen UI when it is requested and both input and output are terminals,
+// and the line UI otherwise; both draw the game with renderer and log the input of the player with logger.
+// The full-screen UI draws its keyboard with the options of the renderer. JSON is read by programs rather than
+// drawn for the player, so the JSON renderer always uses the line UI.
+func NewGameUI(
+	fullscreen bool,
+	renderer Renderer,
+	options RenderOptions,
+	input, output *os.File,
+	logger *slog.Logger,
+) GameUI {
+	if !fullscreen {
+		return NewLineUI(renderer, input, output, logger)
+	}
+
+	if _, isJSON := renderer.(*JSONRenderer); isJSON {
+		logger.Info("Full-screen mode does not support the json renderer, so the line mode is used")
+		return NewLineUI(renderer, input, output, logger)
+	}
+
+	if !term.IsTerminal(int(input.Fd())) || !term.IsTerminal(int(output.Fd())) {
+		logger.Info("Full-screen mode requires a terminal, so the line mode is used")
+		return NewLineUI(renderer, input, output, logger)
+	}
+
+	ui, err := newFullscreenUI(renderer, options, input, output, logger)
+	if err != nil {
+		logger.Error("starting full-screen mode", slog.String("error", err.Error()))
+		return NewLineUI(renderer, input, output, logger)
+	}
+
+	return ui
+}
+
+// LineUI prints every state of the game below the previous one and reads letters line by line.
+type LineUI struct {
+	renderer Renderer
+	input    *InputReader
+	output   io.Writer
+	logger   *slog.Logger
+}
+
+// NewLineUI returns the line UI reading the player from input and drawing the game on output with renderer.
+func NewLineUI(renderer Renderer, input io.Reader, output io.Writer, logger *slog.Logger) *LineUI {
+	return &LineUI{
+		renderer: renderer,
+		input:    NewInputReader(input, output, logger),
+		output:   output,
+		logger:   logger,
+	}
+}
+
+func (ui *LineUI) ShowGame(game *domain.Game) {
+	if err := ui.renderer.Render(ui.output, game.Snapshot()); err != nil {
+		ui.logger.Error("rendering game", slog.String("error", err.Error()))
+	}
+}
+
+func (ui *LineUI) ShowMessage(message string) {
+	fmt.Fprintln(ui.output, message)
+}
+
+func (ui *LineUI) ReadInput(ctx context.Context) (PlayerInput, error) {
+	return ui.input.ReadInput(ctx)
+}
+
+func (ui *LineUI) Close() {}
+
+// FullscreenUI redraws the game in place and reads single keypresses from a terminal in raw mode.
+type FullscreenUI struct {
+	renderer Renderer
+	options  RenderOptions
+	input    *os.File
+	output   *os.File
+	oldState *term.State
+	reader   *bufio.Reader
+	keys     chan keyPress
+	start    sync.Once
+	game     *domain.Game
+	messages []string
+	logger   *slog.Logger
+}
+
+func newFullscreenUI(renderer Renderer, options RenderOptions, input, output *os.File, logger *slog.Logger) (*FullscreenUI, error) {
+	oldState, err := term.MakeRaw(int(input.Fd()))
+	if err != nil {
+		return nil, fmt.Errorf("making terminal raw: %w", err)
+	}
+
+	fmt.Fprint(output, hideCursor)
+
+	return &FullscreenUI{
+		renderer: renderer,
+		options:  options,
+		input:    input,
+		output:   output,
+		oldState: oldState,
+		reader:   bufio.NewReader(input),
+		keys:     make(chan keyPress),
+		logger:   logger,
+	}, nil
+}
+
+type keyPress struct {
+	key rune
+	err error
+}
+
+func (ui *FullscreenUI) ShowGame(game *domain.Game) {
+	// Messages shown before the first game, e.g. about a random difficulty, stay on the screen with it.
+	if ui.game != nil {
+		ui.messages = ui.messages[:0]
+	}
+
+	ui.game = game
+	ui.redraw()
+}
+
+func (ui *FullscreenUI) ShowMessage(message string) {
+	ui.messages = append(ui.messages, message)
+	ui.redraw()
+}
+
+// ReadInput waits for a single letter key, or for a command typed after ":" and confirmed with Enter,
+// ignoring other keys and escape sequences. While it waits, the countdown of a game with a time limit
+// is redrawn every second.
+func (ui *FullscreenUI) ReadInput(ctx context.Context) (PlayerInput, error) {
+	var tick <-chan time.Time
+
+	if ui.game != nil {
+		if _, limited := ui.game.GetTimeLeft(); limited {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+
+			tick = ticker.C
+		}
+	}
+
+	for {
+		key, err := ui.readKey(ctx, tick)
+		if err != nil {
+			return PlayerInput{}, err
+		}
+
+		input := string(key)
+
+		if key == keyCommand {
+			if input, err = ui.readCommand(ctx); err != nil {
+				return PlayerInput{}, err
+			}
+		}
+
+		ui.logger.Info("User input", slog.String("input", input))
+
+		if playerInput, ok := parseInput(input); ok {
+			return playerInput, nil
+		}
+
+		ui.ShowMessage(Localize(MessageWrongInput))
+	}
+}
+
+// readKey returns the next key until ctx is done, turning Ctrl+C and Ctrl+D into ErrInterrupted,
+// and redraws the screen on every tick until then. The keys are read in the background,
+// as a read from the terminal can not be cancelled.
+func (ui *FullscreenUI) readKey(ctx context.Context, tick <-chan time.Time) (rune, error) {
+	ui.start.Do(func() {
+		go ui.readKeys()
+	})
+
+	var press keyPress
+
+	for waiting := true; waiting; {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-tick:
+			ui.redraw()
+		case press = <-ui.keys:
+			waiting = false
+		}
+	}
+
+	if press.err != nil {
+		return 0, press.err
+	}
+
+	if press.key == keyInterrupt || press.key == keyEndOfFile {
+		return 0, ErrInterrupted
+	}
+
+	return press.key, nil
+}
+
+// readKeys sends the keys of the terminal, dropping escape sequences, until reading fails.
+func (ui *FullscreenUI) readKeys() {
+	for {
+		key, _, err := ui.reader.ReadRune()
+		if err != nil {
+			ui.logger.Error("reading key", slog.String("error", err.Error()))
+			ui.keys <- keyPress{err: err}
+
+			return
+		}
+
+		if key == keyEscape {
+			// Arrows and function keys send sequences like ESC [ A, drop the rest of them.
+			ui.reader.Discard(ui.reader.Buffered()) //nolint:errcheck // Discarding buffered bytes can not fail.
+			continue
+		}
+
+		ui.keys <- keyPress{key: key}
+	}
+}
+
+// readCommand echoes the keys typed after ":" until Enter and returns them with the prefix.
+func (ui *FullscreenUI) readCommand(ctx context.Context) (string, error) {
+	command := []rune(commandPrefix)
+
+	fmt.Fprint(ui.output, commandPrefix)
+
+	for {
+		// The command being typed would be cleared by a redraw, so the countdown stops meanwhile.
+		key, err := ui.readKey(ctx, nil)
+		if err != nil {
+			return "", err
+		}
+
+		switch {
+		case key == keyEnter || key == '\n':
+			return string(command), nil
+		case key == keyBackspace && len(command) > 1:
+			command = command[:len(command)-1]
+
+			fmt.Fprint(ui.output, "\b \b")
+		case unicode.IsPrint(key):
+			command = append(command, key)
+
+			fmt.Fprint(ui.output, string(key))
+		}
+	}
+}
+
+func (ui *FullscreenUI) Close() {
+	fmt.Fprint(ui.output, showCursor+"\r\n")
+
+	if err := term.Restore(int(ui.input.Fd()), ui.oldState); err != nil {
+		ui.logger.Error("restoring terminal", slog.String("error", err.Error()))
+	}
+}
+
+func (ui *FullscreenUI) redraw() {
+	var screen bytes.Buffer
+
+	screen.WriteString(clearScreen)
+
+	if ui.game == nil {
+		return
+	}
+
+	snapshot := ui.game.Snapshot()
+
+	if err := ui.renderer.Render(&screen, snapshot); err != nil {
+		ui.logger.Error("rendering game", slog.String("error", err.Error()))
+	}
+
+	writeKeyboard(&screen, snapshot, ui.options)
+
+	for _, message := range ui.messages {
+		fmt.Fprintln(&screen, message)
+	}
+
+	if !snapshot.IsOver() {
+		screen.WriteString(Localize(MessageEnterLetter))
+	}
+
+	// Raw mode does not translate "\n" into "\r\n", so the lines have to be returned to the first column explicitly.
+	if _, err := io.WriteString(ui.output, strings.ReplaceAll(screen.String(), "\n", "\r\n")); err != nil {
+		ui.logger.Error("drawing screen", slog.String("error", err.Error()))
+	}
+}
+
+// writeKeyboard writes the on-screen keyboard of the language of the word: unused letters as is, hits in brackets
+// and misses as dots, colored with the theme of the options.
+func writeKeyboard(w io.Writer, snapshot domain.GameSnapshot, options RenderOptions) {
+	layout, exists := keyboardLayouts[wordLanguage(snapshot, options.Language)]
+	if !exists {
+		layout = keyboardLayouts[domain.LanguageEnglish]
+	}
+
+	fmt.Fprintln(w)
+
+	for i, row := range layout {
+		var line strings.Builder
+
+		line.WriteString(strings.Repeat(" ", i+1))
+
+		for _, letter := range row {
+			switch {
+			case !slices.Contains(snapshot.Guesses, string(letter)):
+				fmt.Fprintf(&line, " %c ", unicode.ToUpper(letter))
+			case slices.Contains(snapshot.Hits, string(letter)):
+				line.WriteString(colorize(fmt.Sprintf("[%c]", unicode.ToUpper(letter)), options.Theme.Hit))
+			default:
+				line.WriteString(colorize(" · ", options.Theme.Miss))
+			}
+		}
+
+		fmt.Fprintln(w, line.String())
+	}
+
+	fmt.Fprintln(w)
+}
+
+// TerminalWidth returns the number of columns of the terminal on stdout, then of the COLUMNS variable,
+// and fallback when neither is known.
+func TerminalWidth(fallback int) int {
+	if columns, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && columns > 0 {
+		return columns
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return fallback
+}
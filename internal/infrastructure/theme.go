@@ -0,0 +1,93 @@
+package infrastructure
+
+import (
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const colorReset = "\x1b[0m"
+
+// Theme holds the ANSI escape sequences used to color the parts of the menu; empty sequences leave the text as is.
+type Theme struct {
+	Hit     string
+	Miss    string
+	Hint    string
+	Safe    string
+	Warning string
+	Danger  string
+}
+
+// noColorTheme is used when colors are disabled or not supported by the output.
+var noColorTheme = Theme{}
+
+var themes = map[string]Theme{
+	"default": {
+		Hit:     "\x1b[32m",
+		Miss:    "\x1b[31m",
+		Hint:    "\x1b[33m",
+		Safe:    "\x1b[32m",
+		Warning: "\x1b[33m",
+		Danger:  "\x1b[31m",
+	},
+	"contrast": {
+		Hit:     "\x1b[1;92m",
+		Miss:    "\x1b[1;91m",
+		Hint:    "\x1b[1;30;103m",
+		Safe:    "\x1b[1;97m",
+		Warning: "\x1b[1;93m",
+		Danger:  "\x1b[1;91m",
+	},
+	"none": noColorTheme,
+}
+
+// ThemeNames returns the names DetectTheme knows, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// DetectTheme returns the theme with the given name, or no colors when NO_COLOR is set or stdout is not a terminal.
+func DetectTheme(name string) Theme {
+	if os.Getenv("NO_COLOR") != "" || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return noColorTheme
+	}
+
+	theme, exists := themes[strings.ToLower(name)]
+	if !exists {
+		slog.Info("Theme not found in list of available values, so the default value is set - default", slog.String("theme", name))
+		return themes["default"]
+	}
+
+	return theme
+}
+
+// dangerColor returns the color of the gallows for the share of attempts used.
+func (theme Theme) dangerColor(attempts, maxAttempts int) string {
+	switch {
+	case maxAttempts <= 0 || attempts*3 >= maxAttempts*2:
+		return theme.Danger
+	case attempts*3 >= maxAttempts:
+		return theme.Warning
+	default:
+		return theme.Safe
+	}
+}
+
+// colorize wraps text in the color sequence, leaving it as is when the color is empty.
+func colorize(text, color string) string {
+	if color == "" || text == "" {
+		return text
+	}
+
+	return color + text + colorReset
+}
@@ -2,35 +2,171 @@ package infrastructure
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
-	"regexp"
 	"strings"
+	"sync"
 	"unicode"
+	"unicode/utf8"
 )
 
+// commandPrefix starts a command instead of a letter, for example ":hint".
+const commandPrefix = ":"
+
+type Command string
+
+const (
+	// CommandHint asks for the help the hint policy of the game allows.
+	CommandHint Command = "hint"
+)
+
+// PlayerInput is either a guessed letter or, when Command is set, a command.
+type PlayerInput struct {
+	Letter  rune
+	Command Command
+}
+
+// GetLetterFromUser asks for a letter on stdin until one is entered; commands are wrong input here.
+// The game reads the player with InputReader, which also accepts commands and can be cancelled.
 func GetLetterFromUser() (rune, error) {
-	isAlpha := regexp.MustCompile(`^[A-Za-z]$`).MatchString
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
-		fmt.Print("Enter one letter: ")
-
-		input, err := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-
-		slog.Info("User input", slog.String("input", input))
+		fmt.Print(Localize(MessageEnterLetter))
 
+		line, err := reader.ReadString('\n')
 		if err != nil {
 			slog.Error("reading user input", slog.String("error", err.Error()))
 			return 0, err
 		}
 
-		if len(input) == 1 && isAlpha(input) {
-			return unicode.ToLower(rune(input[0])), nil
+		if input, ok := parseInput(strings.TrimSpace(line)); ok && input.Command == "" {
+			return input.Letter, nil
 		}
 
-		fmt.Println("Wrong input. Please enter one letter.")
+		fmt.Println(Localize(MessageWrongInput))
+	}
+}
+
+// InputReader reads the guesses and commands of the player line by line, asking again after wrong input.
+// The lines are read ahead in the background, so several inputs can come from one piped stream
+// and waiting for the player can be cancelled.
+type InputReader struct {
+	reader *bufio.Reader
+	prompt io.Writer
+	lines  chan inputLine
+	start  sync.Once
+	logger *slog.Logger
+}
+
+type inputLine struct {
+	text string
+	err  error
+}
+
+// NewInputReader returns a reader of the lines of r that writes the prompts to prompt and logs the lines with logger.
+func NewInputReader(r io.Reader, prompt io.Writer, logger *slog.Logger) *InputReader {
+	return &InputReader{
+		reader: bufio.NewReader(r),
+		prompt: prompt,
+		lines:  make(chan inputLine),
+		logger: logger,
 	}
 }
+
+// ReadInput waits for a letter or a command until ctx is done, in which case it returns the error of ctx.
+// A line typed after that is returned by the next call.
+func (r *InputReader) ReadInput(ctx context.Context) (PlayerInput, error) {
+	for {
+		fmt.Fprint(r.prompt, Localize(MessageEnterLetter))
+
+		line, err := r.nextLine(ctx)
+		if err != nil {
+			// End the line of the prompt, so the messages shown next start on their own line.
+			fmt.Fprintln(r.prompt)
+			return PlayerInput{}, err
+		}
+
+		input, err := strings.TrimSpace(line.text), line.err
+
+		r.logger.Info("User input", slog.String("input", input))
+
+		// The last line may end without a line break, so it is accepted together with io.EOF.
+		if err != nil && (input == "" || !errors.Is(err, io.EOF)) {
+			r.logger.Error("reading user input", slog.String("error", err.Error()))
+			return PlayerInput{}, err
+		}
+
+		if playerInput, ok := parseInput(input); ok {
+			return playerInput, nil
+		}
+
+		fmt.Fprintln(r.prompt, Localize(MessageWrongInput))
+
+		if err != nil {
+			r.logger.Error("reading user input", slog.String("error", err.Error()))
+			return PlayerInput{}, err
+		}
+	}
+}
+
+// nextLine returns the next line read, or the error of ctx when it is done first.
+func (r *InputReader) nextLine(ctx context.Context) (inputLine, error) {
+	r.start.Do(func() {
+		go r.readLines()
+	})
+
+	select {
+	case <-ctx.Done():
+		return inputLine{}, ctx.Err()
+	case line, ok := <-r.lines:
+		if !ok {
+			return inputLine{err: io.EOF}, nil
+		}
+
+		return line, nil
+	}
+}
+
+// readLines sends the lines of the reader until it fails, e.g. at the end of the input.
+func (r *InputReader) readLines() {
+	defer close(r.lines)
+
+	for {
+		text, err := r.reader.ReadString('\n')
+		r.lines <- inputLine{text: text, err: err}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parseInput accepts a single letter or a known command.
+func parseInput(input string) (PlayerInput, bool) {
+	if name, isCommand := strings.CutPrefix(input, commandPrefix); isCommand {
+		if command := Command(strings.ToLower(strings.TrimSpace(name))); command == CommandHint {
+			return PlayerInput{Command: command}, true
+		}
+
+		return PlayerInput{}, false
+	}
+
+	letter, ok := parseLetter(input)
+
+	return PlayerInput{Letter: letter}, ok
+}
+
+// parseLetter accepts a single letter of any alphabet, so word packs in other languages can be played.
+func parseLetter(input string) (rune, bool) {
+	letter, size := utf8.DecodeRuneInString(input)
+	if size == 0 || size != len(input) || !unicode.IsLetter(letter) {
+		return 0, false
+	}
+
+	return unicode.ToLower(letter), true
+}
@@ -0,0 +1,251 @@
+package infrastructure
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 is mandated by the WebSocket handshake (RFC 6455).
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	websocketGUID       = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	maxWebsocketPayload = 4096
+
+	opcodeContinuation = 0x0
+	opcodeText         = 0x1
+	opcodeBinary       = 0x2
+	opcodeClose        = 0x8
+	opcodePing         = 0x9
+	opcodePong         = 0xA
+
+	// Status codes of the close frames sent when the client breaks the protocol.
+	closeProtocolError   = 1002
+	closeUnsupportedData = 1003
+	closeMessageTooBig   = 1009
+)
+
+var errWebsocketClosed = errors.New("websocket closed by peer")
+
+// websocketError is a violation of the protocol by the client, answered with a close frame of Code.
+type websocketError struct {
+	Code    uint16
+	Message string
+}
+
+func (e *websocketError) Error() string {
+	return e.Message
+}
+
+// websocketConn is a minimal server side RFC 6455 connection that exchanges text messages.
+type websocketConn struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	writeMu sync.Mutex
+	// closing makes sure a single close frame is sent, whichever side starts the closing.
+	closing sync.Once
+}
+
+// upgradeWebsocket completes the handshake of a WebSocket request. Requests from pages of other sites are refused,
+// so they can not make the browser of a visitor play in the room; clients other than browsers send no Origin.
+func upgradeWebsocket(w http.ResponseWriter, r *http.Request) (*websocketConn, error) {
+	if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(origin, r.Host) {
+		http.Error(w, "Cross-origin WebSocket requests are not allowed", http.StatusForbidden)
+		return nil, fmt.Errorf("upgrading connection: origin %s does not match host %s", origin, r.Host)
+	}
+
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		!strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") {
+		http.Error(w, "Expected WebSocket upgrade", http.StatusBadRequest)
+		return nil, fmt.Errorf("upgrading connection: not a websocket request")
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "Missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, fmt.Errorf("upgrading connection: missing key")
+	}
+
+	conn, buffer, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("hijacking connection: %w", err)
+	}
+
+	hash := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec // See import comment.
+	accept := base64.StdEncoding.EncodeToString(hash[:])
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"
+
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("writing handshake: %w", err)
+	}
+
+	return &websocketConn{conn: conn, reader: buffer.Reader}, nil
+}
+
+// sameOrigin reports whether the origin of a request names the host it was sent to.
+func sameOrigin(origin, host string) bool {
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(originURL.Host, host)
+}
+
+// ReadMessage returns the next text message, answering pings on the way. When the client breaks the protocol,
+// the connection is closed with the status code of the violation.
+func (ws *websocketConn) ReadMessage() (string, error) {
+	message, err := ws.readMessage()
+
+	if protocolErr := new(websocketError); errors.As(err, &protocolErr) {
+		ws.writeClose(binary.BigEndian.AppendUint16(nil, protocolErr.Code))
+	}
+
+	return message, err
+}
+
+func (ws *websocketConn) readMessage() (string, error) {
+	var message []byte
+
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return "", err
+		}
+
+		switch opcode {
+		case opcodeText, opcodeContinuation:
+			message = append(message, payload...)
+			if len(message) > maxWebsocketPayload {
+				return "", &websocketError{Code: closeMessageTooBig, Message: "reading message: message too large"}
+			}
+
+			if fin {
+				return string(message), nil
+			}
+		case opcodePing:
+			if err := ws.writeFrame(opcodePong, payload); err != nil {
+				return "", err
+			}
+		case opcodePong:
+		case opcodeClose:
+			ws.writeClose(nil)
+			return "", errWebsocketClosed
+		case opcodeBinary:
+			return "", &websocketError{Code: closeUnsupportedData, Message: "reading message: binary messages are not supported"}
+		default:
+			return "", &websocketError{Code: closeProtocolError, Message: fmt.Sprintf("reading message: unknown opcode %#x", opcode)}
+		}
+	}
+}
+
+func (ws *websocketConn) WriteMessage(message []byte) error {
+	return ws.writeFrame(opcodeText, message)
+}
+
+func (ws *websocketConn) Close() error {
+	ws.writeClose(nil)
+	return ws.conn.Close()
+}
+
+// writeClose sends the close frame with the payload unless one was sent already.
+func (ws *websocketConn) writeClose(payload []byte) {
+	ws.closing.Do(func() {
+		_ = ws.writeFrame(opcodeClose, payload)
+	})
+}
+
+func (ws *websocketConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(ws.reader, header); err != nil {
+		return false, 0, nil, fmt.Errorf("reading frame header: %w", err)
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+
+	// Clients must mask every frame and may not use the reserved bits without an extension.
+	if !masked || header[0]&0x70 != 0 {
+		return false, 0, nil, &websocketError{Code: closeProtocolError, Message: "reading frame: unmasked frame or reserved bits set"}
+	}
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(ws.reader, extended); err != nil {
+			return false, 0, nil, fmt.Errorf("reading frame length: %w", err)
+		}
+
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(ws.reader, extended); err != nil {
+			return false, 0, nil, fmt.Errorf("reading frame length: %w", err)
+		}
+
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	if length > maxWebsocketPayload {
+		return false, 0, nil, &websocketError{
+			Code:    closeMessageTooBig,
+			Message: fmt.Sprintf("reading frame: payload of %d bytes is too large", length),
+		}
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(ws.reader, mask[:]); err != nil {
+		return false, 0, nil, fmt.Errorf("reading frame mask: %w", err)
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(ws.reader, payload); err != nil {
+		return false, 0, nil, fmt.Errorf("reading frame payload: %w", err)
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+func (ws *websocketConn) writeFrame(opcode byte, payload []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+
+	frame := []byte{0x80 | opcode}
+
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	frame = append(frame, payload...)
+
+	if _, err := ws.conn.Write(frame); err != nil {
+		return fmt.Errorf("writing frame: %w", err)
+	}
+
+	return nil
+}
@@ -11,6 +11,24 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 )
 
+const (
+	schemaFileName      = "schema.json"
+	defaultWordPackName = "words.json"
+	// metaKey holds the pack metadata; it can not clash with difficulties, which consist of letters only.
+	metaKey = "_meta"
+)
+
+type wordPackMeta struct {
+	Language domain.Language           `json:"language"`
+	Attempts map[domain.Difficulty]int `json:"attempts"`
+	Weights  domain.SelectionWeights   `json:"weights"`
+}
+
+type wordPack struct {
+	meta  wordPackMeta
+	words map[domain.Difficulty]map[domain.Category][]domain.WordHintPair
+}
+
 func CreateProviderFromJSONFile(filePath string) (*domain.DefaultWordProvider, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -23,14 +41,18 @@ func CreateProviderFromJSONFile(filePath string) (*domain.DefaultWordProvider, e
 		return nil, fmt.Errorf("validating JSON: %w", err)
 	}
 
-	var wordsList map[domain.Difficulty]map[domain.Category][]domain.WordHintPair
-
-	if err := json.Unmarshal(data, &wordsList); err != nil {
+	pack, err := unmarshalWordPack(data)
+	if err != nil {
 		slog.Error("unmarshalling JSON file", slog.String("filePath", filePath), slog.String("error", err.Error()))
 		return nil, fmt.Errorf("unmarshalling JSON: %w", err)
 	}
 
-	provider := &domain.DefaultWordProvider{Words: wordsList}
+	provider := &domain.DefaultWordProvider{
+		Words:    pack.words,
+		Language: pack.meta.Language,
+		Attempts: pack.meta.Attempts,
+		Weights:  pack.meta.Weights,
+	}
 	if err := provider.UpdateUniqueCategoriesAndDifficulties(); err != nil {
 		slog.Error(
 			"updating unique categories and difficulties",
@@ -43,8 +65,77 @@ func CreateProviderFromJSONFile(filePath string) (*domain.DefaultWordProvider, e
 	return provider, nil
 }
 
+// FindWordPack returns the path of the word pack in dir that declares lang, or of the default words.json pack.
+func FindWordPack(dir string, lang domain.Language) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		slog.Error("searching word packs", slog.String("dir", dir), slog.String("error", err.Error()))
+		return "", fmt.Errorf("searching word packs: %w", err)
+	}
+
+	for _, path := range paths {
+		if filepath.Base(path) == schemaFileName {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			slog.Error("reading word pack", slog.String("filePath", path), slog.String("error", err.Error()))
+			continue
+		}
+
+		var pack struct {
+			Meta wordPackMeta `json:"_meta"`
+		}
+
+		if err := json.Unmarshal(data, &pack); err != nil {
+			continue
+		}
+
+		if pack.Meta.Language == lang {
+			return path, nil
+		}
+	}
+
+	slog.Info("Word pack for language not found, so the default pack is used", slog.String("lang", string(lang)))
+
+	return filepath.Join(dir, defaultWordPackName), nil
+}
+
+func unmarshalWordPack(data []byte) (*wordPack, error) {
+	var sections map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return nil, err
+	}
+
+	pack := &wordPack{
+		meta:  wordPackMeta{Language: domain.LanguageEnglish},
+		words: make(map[domain.Difficulty]map[domain.Category][]domain.WordHintPair, len(sections)),
+	}
+
+	for key, section := range sections {
+		if key == metaKey {
+			if err := json.Unmarshal(section, &pack.meta); err != nil {
+				return nil, fmt.Errorf("unmarshalling %s: %w", metaKey, err)
+			}
+
+			continue
+		}
+
+		var categories map[domain.Category][]domain.WordHintPair
+		if err := json.Unmarshal(section, &categories); err != nil {
+			return nil, fmt.Errorf("unmarshalling difficulty %s: %w", key, err)
+		}
+
+		pack.words[domain.Difficulty(key)] = categories
+	}
+
+	return pack, nil
+}
+
 func validateJSON(jsonData *[]byte) error {
-	absPathSchemaJSONFile, err := filepath.Abs(filepath.Join("..", "..", "files", "schema.json"))
+	absPathSchemaJSONFile, err := filepath.Abs(filepath.Join("..", "..", "files", schemaFileName))
 	if err != nil {
 		slog.Error("getting schema path", slog.String("error", err.Error()))
 		return fmt.Errorf("getting schema path: %w", err)
@@ -0,0 +1,422 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		env    map[string]string
+		config string
+		check  func(t *testing.T, config *cmd.GameConfig)
+	}{
+		{
+			name: "defaults",
+			args: nil,
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, cmd.CommandPlay, config.Command)
+				assert.Empty(t, config.Difficulty)
+				assert.Empty(t, config.Category)
+				assert.False(t, config.Strict)
+				assert.Equal(t, domain.DefaultHintPolicy, config.HintPolicy)
+				assert.Equal(t, domain.SelectionUniformCategories, config.Selection)
+				assert.Equal(t, slog.LevelInfo, config.Log.Level)
+			},
+		},
+		{
+			name: "play flags",
+			args: []string{"play", "--difficulty", "Hard", "--category", "animals", "--strict", "--hint", "after:2", "--attempts", "4"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, domain.Difficulty("hard"), config.Difficulty)
+				assert.Equal(t, domain.Category("animals"), config.Category)
+				assert.True(t, config.Strict)
+				assert.Equal(t, domain.HintPolicy{Mode: domain.HintModeAfter, Misses: 2}, config.HintPolicy)
+				assert.Equal(t, 4, config.Attempts)
+			},
+		},
+		{
+			name: "explicit random value",
+			args: []string{"--difficulty", "random", "--category", "RANDOM"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Empty(t, config.Difficulty)
+				assert.Empty(t, config.Category)
+			},
+		},
+		{
+			name: "invalid values fall back to defaults",
+			args: []string{"--attempts", "-3", "--hint", "sometimes", "--selection", "best", "--min-length", "-1"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, 0, config.Attempts)
+				assert.Equal(t, domain.DefaultHintPolicy, config.HintPolicy)
+				assert.Equal(t, domain.SelectionUniformCategories, config.Selection)
+				assert.Equal(t, 0, config.Filter.MinLength)
+			},
+		},
+		{
+			name: "names of the renderer, theme and language",
+			args: []string{"--renderer", "plain", "--theme", "contrast", "--lang", "ru"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, "plain", config.Renderer)
+				assert.Equal(t, "contrast", config.Theme)
+				assert.Equal(t, "ru", config.Language)
+			},
+		},
+		{
+			name: "accessible mode selects its renderer",
+			args: []string{"--accessible", "--renderer", "json", "--fullscreen"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, "accessible", config.Renderer)
+				assert.False(t, config.Fullscreen)
+			},
+		},
+		{
+			name: "selection weights",
+			args: []string{"--selection", "weights", "--difficulty-weights", "Hard=2, easy=0,medium=-1", "--category-weights", "animals"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, domain.SelectionWeighted, config.Selection)
+				assert.Equal(t, map[domain.Difficulty]int{"hard": 2, "easy": 0}, config.Weights.Difficulties)
+				assert.Nil(t, config.Weights.Categories)
+			},
+		},
+		{
+			name:   "selection weights from config file",
+			config: "category-weights = \"animals=3,cars=0\"\n",
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, map[domain.Category]int{"animals": 3, "cars": 0}, config.Weights.Categories)
+			},
+		},
+		{
+			name: "list command with flags after the topic",
+			args: []string{"list", "categories", "--difficulty", "easy"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, cmd.CommandList, config.Command)
+				assert.Equal(t, []string{"categories"}, config.Args)
+				assert.Equal(t, domain.Difficulty("easy"), config.Difficulty)
+			},
+		},
+		{
+			name: "serve command hosts the live page by default",
+			args: []string{"serve"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, ":8080", config.Network.ServeAddress)
+			},
+		},
+		{
+			name: "unknown command",
+			args: []string{"plya"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, cmd.Command("plya"), config.Command)
+			},
+		},
+		{
+			name:   "config file",
+			config: "difficulty = \"easy\"\nattempts = 3\n\n[log]\nlevel = \"debug\"\n",
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, domain.Difficulty("easy"), config.Difficulty)
+				assert.Equal(t, 3, config.Attempts)
+				assert.Equal(t, slog.LevelDebug, config.Log.Level)
+			},
+		},
+		{
+			name:   "environment overrides config file",
+			env:    map[string]string{"HANGMAN_DIFFICULTY": "medium", "HANGMAN_LOG_LEVEL": "warn"},
+			config: "difficulty = \"easy\"\n[log]\nlevel = \"debug\"\n",
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, domain.Difficulty("medium"), config.Difficulty)
+				assert.Equal(t, slog.LevelWarn, config.Log.Level)
+			},
+		},
+		{
+			name: "environment without config file",
+			env:  map[string]string{"HANGMAN_ATTEMPTS": "5"},
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, 5, config.Attempts)
+			},
+		},
+		{
+			name:   "empty environment variables are unset",
+			env:    map[string]string{"HANGMAN_DIFFICULTY": "", "HANGMAN_ATTEMPTS": ""},
+			config: "difficulty = \"easy\"\n",
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, domain.Difficulty("easy"), config.Difficulty)
+				assert.Equal(t, 0, config.Attempts)
+			},
+		},
+		{
+			name:   "flags override environment and config file for table keys",
+			args:   []string{"--log-level", "error"},
+			env:    map[string]string{"HANGMAN_LOG_LEVEL": "warn"},
+			config: "[log]\nlevel = \"debug\"\n",
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, slog.LevelError, config.Log.Level)
+			},
+		},
+		{
+			name:   "flags override environment and config file",
+			args:   []string{"--difficulty", "hard"},
+			env:    map[string]string{"HANGMAN_DIFFICULTY": "medium"},
+			config: "difficulty = \"easy\"\n",
+			check: func(t *testing.T, config *cmd.GameConfig) {
+				assert.Equal(t, domain.Difficulty("hard"), config.Difficulty)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configDir := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", configDir)
+
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			if tt.config != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(configDir, "hangman"), 0o700))
+				require.NoError(t, os.WriteFile(filepath.Join(configDir, "hangman", "config.toml"), []byte(tt.config), 0o600))
+			}
+
+			config, err := cmd.Parse(tt.args)
+			require.NoError(t, err)
+			tt.check(t, config)
+		})
+	}
+}
+
+func TestParse_failure(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	_, err := cmd.Parse([]string{"-h"})
+	assert.True(t, errors.Is(err, flag.ErrHelp))
+
+	config, err := cmd.Parse([]string{"--no-such-flag"})
+	assert.Error(t, err)
+	assert.Nil(t, config)
+
+	t.Setenv("HANGMAN_ATTEMPTS", "many")
+
+	config, err = cmd.Parse(nil)
+	assert.Error(t, err)
+	assert.NotNil(t, config)
+
+	t.Setenv("HANGMAN_ATTEMPTS", "")
+	t.Setenv("HANGMAN_CONFIG", filepath.Join(t.TempDir(), "missing.toml"))
+
+	_, err = cmd.Parse(nil)
+	assert.Error(t, err)
+}
+
+func TestParse_strict(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "hint policy",
+			args:    []string{"--hint", "on-demnd"},
+			wantErr: "hint policy 'on-demnd' is not available, did you mean on-demand?",
+		},
+		{
+			name:    "argument of the hint policy",
+			args:    []string{"--hint", "after:0"},
+			wantErr: `hint policy "after:0" needs a positive number of misses`,
+		},
+		{
+			name:    "selection",
+			args:    []string{"--selection", "wieghts"},
+			wantErr: "selection 'wieghts' is not available, did you mean weights?",
+		},
+		{
+			name:    "selection without close values",
+			args:    []string{"--selection", "best"},
+			wantErr: "selection 'best' is not available, available values: categories, words, weights",
+		},
+		{
+			name:    "negative attempts",
+			args:    []string{"--attempts", "-3"},
+			wantErr: "attempts can not be negative, got -3",
+		},
+		{
+			name:    "negative time limit",
+			args:    []string{"--turn-time", "-5s"},
+			wantErr: "turn-time can not be negative, got -5s",
+		},
+		{
+			name:    "negative length",
+			args:    []string{"--max-length", "-1"},
+			wantErr: "max-length can not be negative, got -1",
+		},
+		{
+			name:    "weight",
+			args:    []string{"--category-weights", "animals=-1"},
+			wantErr: `category-weights entry "animals=-1" is not a name and a non-negative number`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := cmd.Parse(append([]string{"--strict"}, tt.args...))
+			require.Error(t, err)
+			assert.NotNil(t, config)
+
+			unknownValueErr := new(domain.UnknownValueError)
+			require.True(t, errors.As(err, &unknownValueErr))
+			assert.Equal(t, tt.wantErr, unknownValueErr.Error())
+
+			_, err = cmd.Parse(tt.args)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManageGame_strictDisplayOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer string
+		theme    string
+	}{
+		{name: "renderer", renderer: "boxx", theme: "default"},
+		{name: "theme", renderer: "box", theme: "dark"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &cmd.GameConfig{Command: cmd.CommandPlay, Strict: true, Renderer: tt.renderer, Theme: tt.theme}
+
+			assert.Equal(t, cmd.ExitBadInput, application.ManageGame(context.Background(), config))
+		})
+	}
+}
+
+func TestChooseCategoryAndDifficulty(t *testing.T) {
+	provider := &domain.DefaultWordProvider{
+		Words: map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+			"easy": {"animals": {{Word: "cat", Hint: "a pet"}}},
+		},
+		AllDifficulties: []domain.Difficulty{"easy"},
+		AllCategories:   []domain.Category{"animals"},
+	}
+
+	ctg, diff, err := application.ChooseCategoryAndDifficulty(provider, "", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Category("animals"), ctg)
+	assert.Equal(t, domain.Difficulty("easy"), diff)
+
+	ctg, _, err = application.ChooseCategoryAndDifficulty(provider, "animls", "easy", false)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Category("animals"), ctg)
+
+	_, _, err = application.ChooseCategoryAndDifficulty(provider, "animls", "easy", true)
+	require.Error(t, err)
+	assert.IsType(t, &domain.UnknownValueError{}, err)
+	assert.Equal(t, "category 'animls' not found in the word pack, did you mean animals?", err.Error())
+	assert.Equal(t, []string{"animals"}, err.(*domain.UnknownValueError).Suggestions)
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "values, comments and tables",
+			content: `# Defaults of the game
+difficulty = "hard" # always hard
+lang = 'ru'
+attempts = 1_0
+fullscreen = true
+
+[log]
+level = "debug"
+file = "/tmp/hangman \"test\".log"
+`,
+			want: map[string]string{
+				"difficulty": "hard",
+				"lang":       "ru",
+				"attempts":   "10",
+				"fullscreen": "true",
+				"log.level":  "debug",
+				"log.file":   `/tmp/hangman "test".log`,
+			},
+		},
+		{
+			name:    "inline table and float",
+			content: "log = { level = \"warn\" }\nattempts = 8.0\n",
+			want: map[string]string{
+				"log.level": "warn",
+				"attempts":  "8",
+			},
+		},
+		{
+			name:    "missing value",
+			content: "difficulty =\n",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated string",
+			content: "difficulty = \"hard\n",
+			wantErr: true,
+		},
+		{
+			name:    "array value",
+			content: "difficulty = [\"hard\"]\n",
+			wantErr: true,
+		},
+		{
+			name:    "key set twice",
+			content: "lang = \"en\"\nlang = \"ru\"\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid table",
+			content: "[log\nlevel = \"debug\"\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			settings, err := cmd.LoadConfigFile(path, false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, settings)
+		})
+	}
+}
+
+func TestLoadConfigFile_missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+
+	settings, err := cmd.LoadConfigFile(path, true)
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+
+	_, err = cmd.LoadConfigFile(path, false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package integration_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWordPack = `{
+  "_meta": {"language": "en"},
+  "easy": {
+    "animals": [{"word": "cat", "hint": "a pet"}, {"word": "dog", "hint": "a pet"}],
+    "fruits": [{"word": "pear", "hint": "a fruit"}]
+  },
+  "hard": {
+    "animals": [{"word": "elephant", "hint": "a large mammal"}]
+  }
+}`
+
+func writeWordPack(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "words.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// lines splits the output of a command into its lines.
+func lines(output string) []string {
+	return strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+}
+
+func TestListWordPack(t *testing.T) {
+	path := writeWordPack(t, testWordPack)
+
+	tests := []struct {
+		name       string
+		topic      cmd.ListTopic
+		difficulty domain.Difficulty
+		wantCode   int
+		wantLines  []string
+	}{
+		{
+			name:     "categories",
+			topic:    cmd.ListCategories,
+			wantCode: cmd.ExitSuccess,
+			wantLines: []string{
+				infrastructure.Localize(infrastructure.MessageListCategory, "animals", 3),
+				infrastructure.Localize(infrastructure.MessageListCategory, "fruits", 1),
+			},
+		},
+		{
+			name:       "categories of a difficulty",
+			topic:      cmd.ListCategories,
+			difficulty: "hard",
+			wantCode:   cmd.ExitSuccess,
+			wantLines: []string{
+				infrastructure.Localize(infrastructure.MessageListCategory, "animals", 1),
+			},
+		},
+		{
+			name:     "difficulties",
+			topic:    cmd.ListDifficulties,
+			wantCode: cmd.ExitSuccess,
+			wantLines: []string{
+				infrastructure.Localize(infrastructure.MessageListDifficulty, "easy", 9, 3),
+				infrastructure.Localize(infrastructure.MessageListDifficulty, "hard", 5, 1),
+			},
+		},
+		{
+			name:       "unknown difficulty",
+			topic:      cmd.ListCategories,
+			difficulty: "hadr",
+			wantCode:   cmd.ExitBadInput,
+			wantLines: []string{
+				infrastructure.Localize(
+					infrastructure.MessageNoDifficulty,
+					"hadr",
+					infrastructure.Localize(infrastructure.MessageDidYouMean, "hard"),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			config := &cmd.GameConfig{WordPack: path, Difficulty: tt.difficulty}
+
+			assert.Equal(t, tt.wantCode, application.ListWordPack(&out, config, tt.topic))
+			assert.Equal(t, tt.wantLines, lines(out.String()))
+		})
+	}
+}
+
+func TestValidateWordPacks(t *testing.T) {
+	valid := writeWordPack(t, testWordPack)
+	invalid := writeWordPack(t, `{"easy": {"animals": [{"word": "cat"}]}}`)
+
+	t.Run("valid pack", func(t *testing.T) {
+		var out bytes.Buffer
+
+		assert.Equal(t, cmd.ExitSuccess, application.ValidateWordPacks(&out, &cmd.GameConfig{}, []string{valid}))
+		assert.Equal(t, []string{infrastructure.Localize(infrastructure.MessageValidPack, valid, 2, 2, 4)}, lines(out.String()))
+	})
+
+	t.Run("invalid pack among valid ones", func(t *testing.T) {
+		var out bytes.Buffer
+
+		assert.Equal(t, cmd.ExitFailure, application.ValidateWordPacks(&out, &cmd.GameConfig{}, []string{invalid, valid}))
+		assert.Contains(t, out.String(), infrastructure.Localize(infrastructure.MessageInvalidPack, invalid))
+		assert.Contains(t, out.String(), infrastructure.Localize(infrastructure.MessageValidPack, valid, 2, 2, 4))
+	})
+}
+
+func TestShowStats(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+
+	var out bytes.Buffer
+
+	assert.Equal(t, cmd.ExitSuccess, application.ShowStats(&out, path))
+	assert.Equal(t, []string{infrastructure.Localize(infrastructure.MessageNoStats)}, lines(out.String()))
+
+	finishedAt := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, record := range []domain.GameRecord{
+		{FinishedAt: finishedAt, Word: "cat", Difficulty: "easy", Result: domain.EventWon},
+		{FinishedAt: finishedAt, Word: "dog", Difficulty: "easy", Result: domain.EventLost},
+		{FinishedAt: finishedAt, Word: "elephant", Difficulty: "hard", Result: domain.EventWon},
+	} {
+		require.NoError(t, infrastructure.AppendGameRecord(path, record))
+	}
+
+	out.Reset()
+
+	assert.Equal(t, cmd.ExitSuccess, application.ShowStats(&out, path))
+	assert.Equal(t, []string{
+		infrastructure.Localize(infrastructure.MessageStatsPlayed, 3),
+		infrastructure.Localize(infrastructure.MessageStatsWon, 2, 66),
+		infrastructure.Localize(infrastructure.MessageStatsLost, 1),
+		infrastructure.Localize(infrastructure.MessageStatsStreak, 1, 1),
+		infrastructure.Localize(infrastructure.MessageStatsDifficulty, "easy", 2, 1),
+		infrastructure.Localize(infrastructure.MessageStatsDifficulty, "hard", 1, 1),
+	}, lines(out.String()))
+}
@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -17,7 +18,7 @@ func TestGame_LetterGuessed_success(t *testing.T) {
 		name  string
 		setup func() *domain.Game
 		args  args
-		want  string
+		want  domain.EventType
 	}{
 		{
 			name: "correct guess",
@@ -29,14 +30,14 @@ func TestGame_LetterGuessed_success(t *testing.T) {
 				return game
 			},
 			args: args{letter: 'l'},
-			want: "Letter guessed",
+			want: domain.EventHit,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			game := tt.setup()
-			if got := game.LetterGuessed(tt.args.letter); got != tt.want {
+			if got := game.LetterGuessed(tt.args.letter).Outcome; got != tt.want {
 				require.Equal(t, tt.want, got)
 			}
 		})
@@ -52,7 +53,7 @@ func TestGame_LetterGuessed_failure(t *testing.T) {
 		name  string
 		setup func() *domain.Game
 		args  args
-		want  string
+		want  domain.EventType
 	}{
 		{
 			name: "incorrect guess",
@@ -64,7 +65,7 @@ func TestGame_LetterGuessed_failure(t *testing.T) {
 				return game
 			},
 			args: args{letter: 'z'},
-			want: "Letter not in word",
+			want: domain.EventMiss,
 		},
 		{
 			name: "already guessed letter",
@@ -76,20 +77,382 @@ func TestGame_LetterGuessed_failure(t *testing.T) {
 				return game
 			},
 			args: args{letter: 'l'},
-			want: "Letter already guessed",
+			want: domain.EventAlreadyGuessed,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			game := tt.setup()
-			if got := game.LetterGuessed(tt.args.letter); got != tt.want {
+			if got := game.LetterGuessed(tt.args.letter).Outcome; got != tt.want {
 				require.Equal(t, tt.want, got)
 			}
 		})
 	}
 }
 
+func TestGame_Subscribe(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputs        []rune
+		wantEvents    []domain.EventType
+		wantPositions [][]int
+	}{
+		{
+			name:          "hits until won",
+			inputs:        []rune{'p', 'a', 'l', 'e'},
+			wantEvents:    []domain.EventType{domain.EventHit, domain.EventHit, domain.EventHit, domain.EventHit, domain.EventWon},
+			wantPositions: [][]int{{1, 2}, {0}, {3}, {4}, nil},
+		},
+		{
+			name:          "misses until lost",
+			inputs:        []rune{'z', 'z', 'x', 'y'},
+			wantEvents:    []domain.EventType{domain.EventMiss, domain.EventHintUnlocked, domain.EventAlreadyGuessed, domain.EventMiss, domain.EventMiss, domain.EventLost},
+			wantPositions: [][]int{nil, nil, nil, nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(domain.WordHintPair{Word: "apple", Hint: "A fruit"}, "fruits", "easy")
+			require.NoError(t, err)
+
+			game.SetMaxAttempts(3)
+
+			var (
+				gotEvents    []domain.EventType
+				gotPositions [][]int
+			)
+
+			unsubscribe := game.Subscribe(func(event domain.GameEvent) {
+				gotEvents = append(gotEvents, event.Type)
+				gotPositions = append(gotPositions, event.Positions)
+			})
+
+			for _, input := range tt.inputs {
+				game.LetterGuessed(input)
+			}
+
+			unsubscribe()
+			game.LetterGuessed('q')
+
+			assert.Equal(t, tt.wantEvents, gotEvents)
+			assert.Equal(t, tt.wantPositions, gotPositions)
+		})
+	}
+}
+
+func TestParseHintPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    domain.HintPolicy
+		wantErr bool
+	}{
+		{
+			name:  "half",
+			value: "half",
+			want:  domain.HintPolicy{Mode: domain.HintModeHalf},
+		},
+		{
+			name:  "on demand in uppercase",
+			value: "On-Demand",
+			want:  domain.HintPolicy{Mode: domain.HintModeOnDemand},
+		},
+		{
+			name:  "after misses",
+			value: "after:3",
+			want:  domain.HintPolicy{Mode: domain.HintModeAfter, Misses: 3},
+		},
+		{
+			name:    "after without misses",
+			value:   "after",
+			wantErr: true,
+		},
+		{
+			name:    "after zero misses",
+			value:   "after:0",
+			wantErr: true,
+		},
+		{
+			name:    "argument for policy without one",
+			value:   "never:2",
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy",
+			value:   "always",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := domain.ParseHintPolicy(tt.value)
+			if tt.wantErr {
+				assert.IsType(t, &domain.HintPolicyError{}, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+		})
+	}
+}
+
+func TestGame_IsHintAvailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  domain.HintPolicy
+		guesses []rune
+		want    bool
+	}{
+		{
+			name:    "half before half of the attempts",
+			policy:  domain.DefaultHintPolicy,
+			guesses: []rune{'x', 'y'},
+			want:    false,
+		},
+		{
+			name:    "half after half of the attempts",
+			policy:  domain.DefaultHintPolicy,
+			guesses: []rune{'x', 'y', 'z'},
+			want:    true,
+		},
+		{
+			name:    "never",
+			policy:  domain.HintPolicy{Mode: domain.HintModeNever},
+			guesses: []rune{'x', 'y', 'z', 'q'},
+			want:    false,
+		},
+		{
+			name:    "after misses not reached",
+			policy:  domain.HintPolicy{Mode: domain.HintModeAfter, Misses: 2},
+			guesses: []rune{'a', 'x'},
+			want:    false,
+		},
+		{
+			name:    "after misses reached",
+			policy:  domain.HintPolicy{Mode: domain.HintModeAfter, Misses: 2},
+			guesses: []rune{'x', 'a', 'y'},
+			want:    true,
+		},
+		{
+			name:    "on demand without request",
+			policy:  domain.HintPolicy{Mode: domain.HintModeOnDemand},
+			guesses: []rune{'x', 'y', 'z', 'q'},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(domain.WordHintPair{Word: "apple", Hint: "A fruit"}, "fruits", "test")
+			require.NoError(t, err)
+
+			game.SetMaxAttempts(6)
+			game.SetHintPolicy(tt.policy)
+
+			for _, letter := range tt.guesses {
+				game.LetterGuessed(letter)
+			}
+
+			assert.Equal(t, tt.want, game.IsHintAvailable())
+		})
+	}
+}
+
+func TestGame_GetUnlockedHints(t *testing.T) {
+	hints := []string{"a fruit", "keeps the doctor away", "starts with a"}
+
+	tests := []struct {
+		name     string
+		policy   domain.HintPolicy
+		guesses  []rune
+		requests int
+		want     []string
+	}{
+		{
+			name:    "half before the threshold",
+			policy:  domain.DefaultHintPolicy,
+			guesses: []rune{'x', 'y'},
+			want:    []string{},
+		},
+		{
+			name:    "half spreads the hints over the attempts left",
+			policy:  domain.DefaultHintPolicy,
+			guesses: []rune{'x', 'y', 'z', 'q'},
+			want:    hints[:2],
+		},
+		{
+			name:    "half shows every hint before the last attempt",
+			policy:  domain.DefaultHintPolicy,
+			guesses: []rune{'x', 'y', 'z', 'q', 'w'},
+			want:    hints,
+		},
+		{
+			name:    "after misses unlocks one hint per miss",
+			policy:  domain.HintPolicy{Mode: domain.HintModeAfter, Misses: 1},
+			guesses: []rune{'x', 'a', 'y'},
+			want:    hints[:2],
+		},
+		{
+			name:     "on demand unlocks one hint per request",
+			policy:   domain.HintPolicy{Mode: domain.HintModeOnDemand},
+			requests: 2,
+			want:     hints[:2],
+		},
+		{
+			name:     "on demand stops after the last hint",
+			policy:   domain.HintPolicy{Mode: domain.HintModeOnDemand},
+			requests: 4,
+			want:     hints,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(
+				domain.WordHintPair{Word: "apple", Hint: "A fruit", Hints: []string{"Keeps the doctor away", "Starts with A"}},
+				"fruits",
+				"test",
+			)
+			require.NoError(t, err)
+
+			game.SetMaxAttempts(6)
+			game.SetHintPolicy(tt.policy)
+
+			for _, letter := range tt.guesses {
+				game.LetterGuessed(letter)
+			}
+
+			for range tt.requests {
+				game.RequestHint()
+			}
+
+			assert.Equal(t, tt.want, game.GetUnlockedHints())
+			assert.Equal(t, min(tt.requests, len(hints)), game.GetAttempts()-len(game.GetMisses()))
+		})
+	}
+}
+
+func TestGame_RequestHint(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       domain.HintPolicy
+		maxAttempts  int
+		requests     int
+		wantEvents   []domain.EventType
+		wantAttempts int
+		wantWord     string
+		wantHint     bool
+	}{
+		{
+			name:         "on demand unlocks the hint once",
+			policy:       domain.HintPolicy{Mode: domain.HintModeOnDemand},
+			maxAttempts:  5,
+			requests:     2,
+			wantEvents:   []domain.EventType{domain.EventHintUnlocked, domain.EventHintDenied},
+			wantAttempts: 1,
+			wantWord:     "___",
+			wantHint:     true,
+		},
+		{
+			name:         "reveal opens letters until the word is guessed",
+			policy:       domain.HintPolicy{Mode: domain.HintModeReveal},
+			maxAttempts:  5,
+			requests:     2,
+			wantEvents:   []domain.EventType{domain.EventLetterRevealed, domain.EventWon, domain.EventHintDenied},
+			wantAttempts: 1,
+			wantWord:     "aaa",
+		},
+		{
+			name:         "reveal of the last letter with the last attempt wins",
+			policy:       domain.HintPolicy{Mode: domain.HintModeReveal},
+			maxAttempts:  1,
+			requests:     1,
+			wantEvents:   []domain.EventType{domain.EventLetterRevealed, domain.EventWon},
+			wantAttempts: 1,
+			wantWord:     "aaa",
+		},
+		{
+			name:         "policy without requests",
+			policy:       domain.DefaultHintPolicy,
+			maxAttempts:  5,
+			requests:     1,
+			wantEvents:   []domain.EventType{domain.EventHintDenied},
+			wantAttempts: 0,
+			wantWord:     "___",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(domain.WordHintPair{Word: "aaa", Hint: "Three letters"}, "test", "test")
+			require.NoError(t, err)
+
+			game.SetMaxAttempts(tt.maxAttempts)
+			game.SetHintPolicy(tt.policy)
+
+			var events []domain.EventType
+
+			game.Subscribe(func(event domain.GameEvent) {
+				events = append(events, event.Type)
+			})
+
+			for range tt.requests {
+				game.RequestHint()
+			}
+
+			assert.Equal(t, tt.wantEvents, events)
+			assert.Equal(t, tt.wantAttempts, game.GetAttempts())
+			assert.Equal(t, tt.wantWord, game.GetWordWithGuesses())
+			assert.Equal(t, tt.wantHint, game.IsHintAvailable())
+		})
+	}
+}
+
+func TestGame_GetGuessHistory(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputs      []rune
+		wantHistory []rune
+		wantHits    []rune
+		wantMisses  []rune
+	}{
+		{
+			name:        "mixed guesses keep their order",
+			inputs:      []rune{'z', 'p', 'x', 'a', 'p'},
+			wantHistory: []rune{'z', 'p', 'x', 'a'},
+			wantHits:    []rune{'p', 'a'},
+			wantMisses:  []rune{'z', 'x'},
+		},
+		{
+			name:        "no guesses",
+			inputs:      nil,
+			wantHistory: nil,
+			wantHits:    nil,
+			wantMisses:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(domain.WordHintPair{Word: "apple", Hint: "A fruit"}, "fruits", "easy")
+			require.NoError(t, err)
+
+			for _, input := range tt.inputs {
+				game.LetterGuessed(input)
+			}
+
+			assert.Equal(t, tt.wantHistory, game.GetGuessHistory())
+			assert.Equal(t, tt.wantHits, game.GetHits())
+			assert.Equal(t, tt.wantMisses, game.GetMisses())
+		})
+	}
+}
+
 func TestGame_GetWordWithGuesses(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -229,10 +592,10 @@ func TestGame_WordGuessed_failure(t *testing.T) {
 
 func TestGame_GameIsOver_success(t *testing.T) {
 	tests := []struct {
-		name        string
-		setup       func() *domain.Game
-		wantIsOver  bool
-		wantMessage string
+		name       string
+		setup      func() *domain.Game
+		wantIsOver bool
+		wantResult domain.EventType
 	}{
 		{
 			name: "word guessed",
@@ -241,8 +604,8 @@ func TestGame_GameIsOver_success(t *testing.T) {
 				game.SetGuesses(map[rune]bool{'a': true, 'p': true, 'l': true, 'e': true})
 				return game
 			},
-			wantIsOver:  true,
-			wantMessage: "Word guessed. You win!",
+			wantIsOver: true,
+			wantResult: domain.EventWon,
 		},
 		{
 			name: "max attempts reached",
@@ -253,26 +616,26 @@ func TestGame_GameIsOver_success(t *testing.T) {
 				game.SetMaxAttempts(5)
 				return game
 			},
-			wantIsOver:  true,
-			wantMessage: "Max attempts reached. You lose! \nWord: apple",
+			wantIsOver: true,
+			wantResult: domain.EventLost,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			game := tt.setup()
-			gotIsOver, gotMessage := game.GameIsOver()
+			gotIsOver, gotResult := game.GameIsOver()
 			require.Equal(t, tt.wantIsOver, gotIsOver)
-			require.Equal(t, tt.wantMessage, gotMessage)
+			require.Equal(t, tt.wantResult, gotResult)
 		})
 	}
 }
 
 func TestGame_GameIsOver_failure(t *testing.T) {
 	tests := []struct {
-		name        string
-		setup       func() *domain.Game
-		wantIsOver  bool
-		wantMessage string
+		name       string
+		setup      func() *domain.Game
+		wantIsOver bool
+		wantResult domain.EventType
 	}{
 		{
 			name: "game continues",
@@ -283,16 +646,16 @@ func TestGame_GameIsOver_failure(t *testing.T) {
 				game.SetMaxAttempts(5)
 				return game
 			},
-			wantIsOver:  false,
-			wantMessage: "",
+			wantIsOver: false,
+			wantResult: "",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			game := tt.setup()
-			gotIsOver, gotMessage := game.GameIsOver()
+			gotIsOver, gotResult := game.GameIsOver()
 			require.Equal(t, tt.wantIsOver, gotIsOver)
-			require.Equal(t, tt.wantMessage, gotMessage)
+			require.Equal(t, tt.wantResult, gotResult)
 		})
 	}
 }
@@ -325,6 +688,43 @@ func TestGame_NewGame_success(t *testing.T) {
 	}
 }
 
+func TestGame_NewGame_attemptsByDifficulty(t *testing.T) {
+	tests := []struct {
+		name         string
+		difficulty   domain.Difficulty
+		wantAttempts int
+	}{
+		{
+			name:         "easy",
+			difficulty:   "easy",
+			wantAttempts: 9,
+		},
+		{
+			name:         "medium",
+			difficulty:   "medium",
+			wantAttempts: 7,
+		},
+		{
+			name:         "hard",
+			difficulty:   "hard",
+			wantAttempts: 5,
+		},
+		{
+			name:         "unknown difficulty",
+			difficulty:   "nightmare",
+			wantAttempts: domain.MaxAttempts,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := domain.NewGame(domain.WordHintPair{Word: "apple", Hint: "A fruit"}, "fruits", tt.difficulty)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAttempts, game.GetMaxAttempts())
+		})
+	}
+}
+
 func TestGame_NewGame_failure(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -374,6 +774,66 @@ func TestGame_NewGame_failure(t *testing.T) {
 	}
 }
 
+func TestGame_TimeLimit(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	var events []domain.EventType
+
+	game.Subscribe(func(event domain.GameEvent) {
+		events = append(events, event.Type)
+	})
+
+	_, limited := game.GetTimeLeft()
+	assert.False(t, limited)
+	assert.False(t, game.ExpireIfTimeIsUp())
+
+	clock := newFakeClock()
+	game.SetClock(clock)
+	game.SetTimeLimit(time.Minute)
+
+	clock.Advance(45 * time.Second)
+
+	left, limited := game.GetTimeLeft()
+	assert.True(t, limited)
+	assert.Equal(t, 15*time.Second, left)
+	assert.False(t, game.ExpireIfTimeIsUp())
+
+	clock.Advance(15 * time.Second)
+
+	assert.True(t, game.ExpireIfTimeIsUp())
+	assert.True(t, game.ExpireIfTimeIsUp())
+	assert.Equal(t, []domain.EventType{domain.EventLost}, events)
+
+	gameIsOver, result := game.GameIsOver()
+	assert.True(t, gameIsOver)
+	assert.Equal(t, domain.EventLost, result)
+}
+
+func TestGame_TurnTimedOut(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	game.SetMaxAttempts(2)
+
+	var events []domain.EventType
+
+	game.Subscribe(func(event domain.GameEvent) {
+		events = append(events, event.Type)
+	})
+
+	assert.Equal(t, domain.EventTurnTimedOut, game.TurnTimedOut().Outcome)
+	assert.Equal(t, 1, game.GetAttempts())
+
+	game.TurnTimedOut()
+	game.TurnTimedOut()
+
+	assert.Equal(t, 2, game.GetAttempts())
+	assert.Equal(t, []domain.EventType{
+		domain.EventTurnTimedOut, domain.EventHintUnlocked, domain.EventTurnTimedOut, domain.EventLost,
+	}, events)
+}
+
 func TestDefaultWordProvider_GetRandomDifficulty_success(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -728,6 +1188,207 @@ func TestDefaultWordProvider_GetRandomWordAndHintFromCategory_failure(t *testing
 	}
 }
 
+func TestDefaultWordProvider_Filter(t *testing.T) {
+	words := map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+		"easy": {
+			"animals": {
+				{Word: "cat", Hint: "a pet", Tags: []string{"pets"}},
+				{Word: "elephant", Hint: "a big animal"},
+			},
+			"phrases": {
+				{Word: "ice cream", Hint: "a dessert", Tags: []string{"food"}},
+			},
+		},
+		"hard": {
+			"animals": {
+				{Word: "axolotl", Hint: "a salamander"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		filter         domain.WordFilter
+		wantDifficulty domain.Difficulty
+		wantCategory   domain.Category
+		wantWord       string
+		wantErr        string
+	}{
+		{
+			name:           "min and max length",
+			filter:         domain.WordFilter{MinLength: 4, MaxLength: 7},
+			wantDifficulty: "hard",
+			wantCategory:   "animals",
+			wantWord:       "axolotl",
+		},
+		{
+			name:           "phrase length does not count spaces",
+			filter:         domain.WordFilter{MinLength: 8, MaxLength: 8},
+			wantDifficulty: "easy",
+		},
+		{
+			name:           "excluded letters and tag",
+			filter:         domain.WordFilter{ExcludeLetters: "XE", Tag: "Pets"},
+			wantDifficulty: "easy",
+			wantCategory:   "animals",
+			wantWord:       "cat",
+		},
+		{
+			name:           "excluded words",
+			filter:         domain.WordFilter{ExcludeWords: []string{"cat", "elephant", "ice cream"}},
+			wantDifficulty: "hard",
+			wantCategory:   "animals",
+			wantWord:       "axolotl",
+		},
+		{
+			name:    "excluded words that empty the pool are counted",
+			filter:  domain.WordFilter{ExcludeWords: []string{"cat"}, Tag: "pets"},
+			wantErr: "no words left after filter exclude-words=1 (1 words before it)",
+		},
+		{
+			name:    "no spaces",
+			filter:  domain.WordFilter{NoSpaces: true, Tag: "food"},
+			wantErr: "no words left after filter words-with-spaces=false (1 words before it)",
+		},
+		{
+			name:    "filter that empties the pool is named",
+			filter:  domain.WordFilter{MinLength: 3, MaxLength: 2, Tag: "pets"},
+			wantErr: "no words left after filter max-length=2 (4 words before it)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &domain.DefaultWordProvider{Words: words, Filter: tt.filter}
+
+			difficulty, err := provider.GetRandomDifficulty()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.IsType(t, &domain.NotFoundError{}, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDifficulty, difficulty)
+
+			if tt.wantWord == "" {
+				return
+			}
+
+			category, err := provider.GetRandomCategoryFromDifficulty(difficulty)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCategory, category)
+
+			pair, err := provider.GetRandomWordAndHintFromCategory(category, difficulty)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantWord, pair.Word)
+		})
+	}
+}
+
+func TestDefaultWordProvider_Strategy(t *testing.T) {
+	words := map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+		"easy": {
+			"tiny": {{Word: "cat", Hint: "a pet"}},
+			"large": {
+				{Word: "apple", Hint: "a fruit"}, {Word: "banana", Hint: "a fruit"}, {Word: "cherry", Hint: "a fruit"},
+				{Word: "grape", Hint: "a fruit"}, {Word: "lemon", Hint: "a fruit"}, {Word: "mango", Hint: "a fruit"},
+				{Word: "melon", Hint: "a fruit"}, {Word: "peach", Hint: "a fruit"}, {Word: "pear", Hint: "a fruit"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		strategy  domain.SelectionStrategy
+		weights   domain.SelectionWeights
+		wantTiny  float64
+		tolerance float64
+	}{
+		{
+			name:      "uniform over categories",
+			strategy:  domain.SelectionUniformCategories,
+			wantTiny:  0.5,
+			tolerance: 0.1,
+		},
+		{
+			name:      "uniform over words",
+			strategy:  domain.SelectionUniformWords,
+			wantTiny:  0.1,
+			tolerance: 0.05,
+		},
+		{
+			name:      "custom weights",
+			strategy:  domain.SelectionWeighted,
+			weights:   domain.SelectionWeights{Categories: map[domain.Category]int{"tiny": 3}},
+			wantTiny:  0.75,
+			tolerance: 0.1,
+		},
+		{
+			name:      "zero weight excludes the category",
+			strategy:  domain.SelectionWeighted,
+			weights:   domain.SelectionWeights{Categories: map[domain.Category]int{"Tiny": 0}},
+			wantTiny:  0,
+			tolerance: 0,
+		},
+		{
+			name:     "config weights over the word pack",
+			strategy: domain.SelectionWeighted,
+			weights: domain.SelectionWeights{Categories: map[domain.Category]int{"tiny": 0}}.Or(
+				domain.SelectionWeights{Categories: map[domain.Category]int{"tiny": 3}},
+			),
+			wantTiny:  0,
+			tolerance: 0,
+		},
+		{
+			name:     "word pack weights missing from the config",
+			strategy: domain.SelectionWeighted,
+			weights: domain.SelectionWeights{Difficulties: map[domain.Difficulty]int{"easy": 2}}.Or(
+				domain.SelectionWeights{Categories: map[domain.Category]int{"tiny": 3}},
+			),
+			wantTiny:  0.75,
+			tolerance: 0.1,
+		},
+	}
+
+	const picks = 2000
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &domain.DefaultWordProvider{Words: words, Strategy: tt.strategy, Weights: tt.weights}
+
+			tiny := 0
+
+			for range picks {
+				category, err := provider.GetRandomCategoryFromDifficulty("easy")
+				require.NoError(t, err)
+
+				if category == "tiny" {
+					tiny++
+				}
+			}
+
+			assert.InDelta(t, tt.wantTiny, float64(tiny)/picks, tt.tolerance)
+		})
+	}
+}
+
+func TestDefaultWordProvider_GetRandomWordAndHintFromCategory_filtered(t *testing.T) {
+	provider := &domain.DefaultWordProvider{
+		Words: map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+			"easy": {"animals": {{Word: "cat", Hint: "a pet"}, {Word: "dog", Hint: "a pet"}}},
+		},
+		Filter: domain.WordFilter{ExcludeLetters: "a", MinLength: 4},
+	}
+
+	_, err := provider.GetRandomWordAndHintFromCategory("animals", "easy")
+	require.Error(t, err)
+	assert.Equal(t, "No words and hints in category 'animals' with difficulty 'easy': "+
+		"no words left after filter min-length=4 (2 words before it)", err.Error())
+}
+
 func TestDefaultWordProvider_NormalizeCase(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -910,3 +1571,163 @@ func TestDefaultWordProvider_UpdateUniqueCategoriesAndDifficulties_failure(t *te
 		})
 	}
 }
+
+func TestDefaultWordProvider_CountWords(t *testing.T) {
+	provider := &domain.DefaultWordProvider{
+		Words: map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+			"easy": {
+				"animals": {{Word: "cat", Hint: "a pet"}, {Word: "dog", Hint: "a pet"}},
+				"fruits":  {{Word: "apple", Hint: "a fruit"}},
+			},
+		},
+	}
+
+	assert.Equal(t, 3, provider.CountWords("easy", ""))
+	assert.Equal(t, 2, provider.CountWords("easy", "animals"))
+	assert.Equal(t, 0, provider.CountWords("hard", ""))
+
+	provider.Filter = domain.WordFilter{MinLength: 4}
+
+	assert.Equal(t, 1, provider.CountWords("easy", ""))
+	assert.Equal(t, 0, provider.CountWords("easy", "animals"))
+}
+
+func TestSummarizeGames(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []domain.GameRecord
+		want    domain.GameStats
+		wantWin int
+	}{
+		{
+			name: "no games",
+			want: domain.GameStats{ByDifficulty: map[domain.Difficulty]domain.DifficultyStats{}},
+		},
+		{
+			name: "streaks and difficulties",
+			records: []domain.GameRecord{
+				{Difficulty: "easy", Result: domain.EventWon},
+				{Difficulty: "easy", Result: domain.EventWon},
+				{Difficulty: "hard", Result: domain.EventLost},
+				{Difficulty: "easy", Result: domain.EventWon},
+			},
+			want: domain.GameStats{
+				Played:        4,
+				Won:           3,
+				CurrentStreak: 1,
+				BestStreak:    2,
+				ByDifficulty: map[domain.Difficulty]domain.DifficultyStats{
+					"easy": {Played: 3, Won: 3},
+					"hard": {Played: 1, Won: 0},
+				},
+			},
+			wantWin: 75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := domain.SummarizeGames(tt.records)
+
+			assert.Equal(t, tt.want, stats)
+			assert.Equal(t, tt.wantWin, stats.WinRate())
+			assert.Equal(t, tt.want.Played-tt.want.Won, stats.Lost())
+		})
+	}
+}
+
+func TestNewGameRecord(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	for _, letter := range "cat" {
+		game.LetterGuessed(letter)
+	}
+
+	finishedAt := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, domain.GameRecord{
+		FinishedAt:  finishedAt,
+		Word:        "cat",
+		Category:    "animals",
+		Difficulty:  "easy",
+		Result:      domain.EventWon,
+		Attempts:    0,
+		MaxAttempts: 9,
+	}, domain.NewGameRecord(game, finishedAt))
+}
+
+func TestNewGameRecord_abandoned(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	game.LetterGuessed('c')
+
+	assert.Equal(t, domain.EventAbandoned, domain.NewGameRecord(game, time.Now()).Result)
+}
+
+func TestBlitzScore_Add(t *testing.T) {
+	var score domain.BlitzScore
+
+	for _, guesses := range []string{"cat", "xyzqwvb", "ca"} {
+		game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+		require.NoError(t, err)
+
+		game.SetMaxAttempts(7)
+
+		for _, letter := range guesses {
+			game.LetterGuessed(letter)
+		}
+
+		score.Add(game)
+	}
+
+	assert.Equal(t, domain.BlitzScore{Played: 3, Solved: 1}, score)
+}
+
+func TestSuggest(t *testing.T) {
+	categories := []domain.Category{"animals", "cars", "cities", "countries", "fruits", "hobbies"}
+
+	tests := []struct {
+		name  string
+		value domain.Category
+		want  []domain.Category
+	}{
+		{
+			name:  "missing letter",
+			value: "animls",
+			want:  []domain.Category{"animals"},
+		},
+		{
+			name:  "different case",
+			value: "FRUTS",
+			want:  []domain.Category{"fruits"},
+		},
+		{
+			name:  "prefix",
+			value: "count",
+			want:  []domain.Category{"countries"},
+		},
+		{
+			name:  "equally close in alphabetical order",
+			value: "cits",
+			want:  []domain.Category{"cars", "cities"},
+		},
+		{
+			name:  "one letter short",
+			value: "hobbie",
+			want:  []domain.Category{"hobbies"},
+		},
+		{
+			name:  "nothing close",
+			value: "xyz",
+			want:  []domain.Category{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.Suggest(tt.value, categories))
+		})
+	}
+}
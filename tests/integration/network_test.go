@@ -0,0 +1,410 @@
+package integration_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/cmd"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// roomClient is a player talking to a hosted room over TCP.
+type roomClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialRoom(t *testing.T, addr string) *roomClient {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	return &roomClient{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (client *roomClient) send(t *testing.T, line string) {
+	t.Helper()
+
+	_, err := client.conn.Write([]byte(line + "\n"))
+	require.NoError(t, err)
+}
+
+// readUntil reads the messages of the room up to the first line containing text.
+func (client *roomClient) readUntil(t *testing.T, text string) {
+	t.Helper()
+
+	for {
+		line, err := client.reader.ReadString('\n')
+		require.NoError(t, err, "waiting for %q", text)
+
+		if strings.Contains(line, text) {
+			return
+		}
+	}
+}
+
+func TestAcceptPlayers(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	room := application.NewRoom(game, application.RoomModeTurns)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	hosted := make(chan error)
+
+	go func() {
+		hosted <- infrastructure.AcceptPlayers(listener, room)
+	}()
+
+	alice := dialRoom(t, listener.Addr().String())
+	alice.readUntil(t, infrastructure.Localize(infrastructure.MessageEnterName))
+	alice.send(t, "alice")
+	alice.readUntil(t, "alice")
+
+	// The second player joins through the client of the game, which relays its input and output.
+	var bobOutput bytes.Buffer
+
+	joined := make(chan error)
+
+	go func() {
+		joined <- infrastructure.JoinRoom(context.Background(), listener.Addr().String(), strings.NewReader("bob\n"), &bobOutput)
+	}()
+
+	alice.readUntil(t, "bob")
+
+	alice.send(t, "c")
+	alice.readUntil(t, infrastructure.Localize(infrastructure.MessagePlayerGuessed, "alice", 'c', ""))
+
+	alice.send(t, "a")
+	alice.readUntil(t, infrastructure.Localize(infrastructure.MessageNotYourTurn, "bob"))
+
+	alice.send(t, "1")
+	alice.readUntil(t, infrastructure.Localize(infrastructure.MessageWrongInput))
+
+	room.Close()
+
+	alice.readUntil(t, strings.Split(infrastructure.AbandonedMessage(game), "\n")[0])
+
+	require.NoError(t, <-joined)
+	require.NoError(t, <-hosted)
+
+	assert.Contains(t, bobOutput.String(), infrastructure.Localize(infrastructure.MessageEnterName))
+	assert.Contains(t, bobOutput.String(), infrastructure.AbandonedMessage(game))
+	assert.Equal(t, "c__", game.GetWordWithGuesses())
+}
+
+func TestHostRoom_listenFails(t *testing.T) {
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer busy.Close()
+
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	room := application.NewRoom(game, application.RoomModeTurns)
+
+	err = application.HostRoom(context.Background(), room, cmd.NetworkOptions{HostAddress: busy.Addr().String()})
+	assert.Error(t, err)
+
+	select {
+	case <-room.Done():
+	default:
+		t.Fatal("room must be closed when it can not be hosted")
+	}
+}
+
+// liveClient speaks the frames of RFC 6455 directly, so the tests control masking, opcodes and lengths.
+type liveClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// handshakeKey is the sample key of RFC 6455, which the server accepts with handshakeAccept.
+const (
+	handshakeKey    = "dGhlIHNhbXBsZSBub25jZQ=="
+	handshakeAccept = "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="
+)
+
+func dialLive(t *testing.T, server *httptest.Server, path, origin string) (*liveClient, *http.Response) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+	request := "GET " + path + " HTTP/1.1\r\n" +
+		"Host: " + server.Listener.Addr().String() + "\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + handshakeKey + "\r\n" +
+		"Sec-WebSocket-Version: 13\r\n"
+	if origin != "" {
+		request += "Origin: " + origin + "\r\n"
+	}
+
+	_, err = conn.Write([]byte(request + "\r\n"))
+	require.NoError(t, err)
+
+	client := &liveClient{conn: conn, reader: bufio.NewReader(conn)}
+
+	response, err := http.ReadResponse(client.reader, nil)
+	require.NoError(t, err)
+
+	defer response.Body.Close()
+
+	return client, response
+}
+
+// writeFrame sends a final frame; clients have to mask their frames, which masked turns off.
+func (client *liveClient) writeFrame(t *testing.T, opcode byte, payload []byte, masked bool) {
+	t.Helper()
+
+	frame := []byte{0x80 | opcode}
+
+	var maskBit byte
+	if masked {
+		maskBit = 0x80
+	}
+
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, maskBit|byte(len(payload)))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+
+	if masked {
+		mask := []byte{0x12, 0x34, 0x56, 0x78}
+		frame = append(frame, mask...)
+
+		for i, b := range payload {
+			frame = append(frame, b^mask[i%4])
+		}
+	} else {
+		frame = append(frame, payload...)
+	}
+
+	_, err := client.conn.Write(frame)
+	require.NoError(t, err)
+}
+
+// readFrame returns the opcode and the payload of the next frame of the server, which are never masked.
+func (client *liveClient) readFrame(t *testing.T) (byte, []byte) {
+	t.Helper()
+
+	header := make([]byte, 2)
+	_, err := io.ReadFull(client.reader, header)
+	require.NoError(t, err)
+
+	length := uint64(header[1] & 0x7F)
+
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		_, err = io.ReadFull(client.reader, extended)
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		_, err = io.ReadFull(client.reader, extended)
+		length = binary.BigEndian.Uint64(extended)
+	}
+
+	require.NoError(t, err)
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(client.reader, payload)
+	require.NoError(t, err)
+
+	return header[0] & 0x0F, payload
+}
+
+// readUntil reads the messages of the server up to the first one of the type, e.g. "hit" or "message",
+// whose text contains text.
+func (client *liveClient) readUntil(t *testing.T, messageType, text string) {
+	t.Helper()
+
+	for {
+		opcode, payload := client.readFrame(t)
+		require.Equal(t, byte(0x1), opcode, "waiting for %q", messageType)
+
+		var message struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+
+		require.NoError(t, json.Unmarshal(payload, &message))
+
+		if message.Type == messageType && strings.Contains(message.Message, text) {
+			return
+		}
+	}
+}
+
+// readClose reads up to the close frame of the server and returns its status code.
+func (client *liveClient) readClose(t *testing.T) uint16 {
+	t.Helper()
+
+	for {
+		opcode, payload := client.readFrame(t)
+		if opcode == 0x8 {
+			require.Len(t, payload, 2)
+			return binary.BigEndian.Uint16(payload)
+		}
+	}
+}
+
+func newLiveServer(t *testing.T) (*httptest.Server, *domain.Game) {
+	t.Helper()
+
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	room := application.NewRoom(game, application.RoomModeRace)
+	handler := infrastructure.NewLiveHandler(room)
+	server := httptest.NewServer(handler)
+
+	t.Cleanup(func() {
+		room.Close()
+		handler.Close()
+		server.Close()
+	})
+
+	return server, game
+}
+
+func TestLiveHandler_handshake(t *testing.T) {
+	server, _ := newLiveServer(t)
+
+	tests := []struct {
+		name       string
+		origin     string
+		wantStatus int
+	}{
+		{
+			name:       "client without origin",
+			wantStatus: http.StatusSwitchingProtocols,
+		},
+		{
+			name:       "page of the game",
+			origin:     server.URL,
+			wantStatus: http.StatusSwitchingProtocols,
+		},
+		{
+			name:       "page of another site",
+			origin:     "https://example.com",
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, response := dialLive(t, server, "/ws?name=alice", tt.origin)
+
+			assert.Equal(t, tt.wantStatus, response.StatusCode)
+
+			if tt.wantStatus == http.StatusSwitchingProtocols {
+				assert.Equal(t, handshakeAccept, response.Header.Get("Sec-WebSocket-Accept"))
+			}
+		})
+	}
+}
+
+func TestLiveHandler_guess(t *testing.T) {
+	server, game := newLiveServer(t)
+
+	player, response := dialLive(t, server, "/ws?name=alice", "")
+	require.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+
+	player.readUntil(t, string(domain.EventState), "")
+
+	player.writeFrame(t, 0x1, []byte(`{"letter": "c"}`), true)
+	player.readUntil(t, string(domain.EventHit), "")
+
+	player.writeFrame(t, 0x1, []byte("1"), true)
+	player.readUntil(t, "message", infrastructure.Localize(infrastructure.MessageWrongInput))
+
+	spectator, response := dialLive(t, server, "/ws", "")
+	require.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+
+	spectator.writeFrame(t, 0x1, []byte("a"), true)
+	spectator.readUntil(t, "message", infrastructure.Localize(infrastructure.MessageSpectator))
+
+	assert.Equal(t, "c__", game.GetWordWithGuesses())
+}
+
+func TestLiveHandler_protocolErrors(t *testing.T) {
+	server, _ := newLiveServer(t)
+
+	tests := []struct {
+		name     string
+		opcode   byte
+		payload  []byte
+		masked   bool
+		wantCode uint16
+	}{
+		{
+			name:     "unmasked frame",
+			opcode:   0x1,
+			payload:  []byte("a"),
+			wantCode: 1002,
+		},
+		{
+			name:     "unknown opcode",
+			opcode:   0x3,
+			payload:  []byte("a"),
+			masked:   true,
+			wantCode: 1002,
+		},
+		{
+			name:     "binary message",
+			opcode:   0x2,
+			payload:  []byte{1},
+			masked:   true,
+			wantCode: 1003,
+		},
+		{
+			name:     "payload over the limit",
+			opcode:   0x1,
+			payload:  bytes.Repeat([]byte("a"), 5000),
+			masked:   true,
+			wantCode: 1009,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, response := dialLive(t, server, "/ws?name=alice", "")
+			require.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+
+			client.writeFrame(t, tt.opcode, tt.payload, tt.masked)
+
+			assert.Equal(t, tt.wantCode, client.readClose(t))
+		})
+	}
+}
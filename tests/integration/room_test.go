@@ -0,0 +1,102 @@
+package integration_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoom_Guess_turns(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "apple", Hint: "A fruit"}, "fruits", "easy")
+	require.NoError(t, err)
+
+	room := application.NewRoom(game, application.RoomModeTurns)
+
+	alice, err := room.Join("alice", func(string) {})
+	require.NoError(t, err)
+
+	bob, err := room.Join("bob", func(string) {})
+	require.NoError(t, err)
+
+	require.NoError(t, room.Guess(alice, 'a'))
+	assert.Error(t, room.Guess(alice, 'p'), "alice must wait for bob")
+	require.NoError(t, room.Guess(bob, 'p'))
+	assert.Error(t, room.Guess(alice, 'p'), "letter already guessed")
+
+	require.NoError(t, room.Guess(alice, 'l'))
+	require.NoError(t, room.Guess(bob, 'e'))
+
+	select {
+	case <-room.Done():
+	default:
+		t.Fatal("room must be done after the word is guessed")
+	}
+
+	assert.Error(t, room.Guess(alice, 'z'))
+}
+
+func TestRoom_Guess_raceConcurrent(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "abcdefghijklmnopqrstuvwxyz", Hint: "Alphabet"}, "letters", "easy")
+	require.NoError(t, err)
+
+	room := application.NewRoom(game, application.RoomModeRace)
+
+	var wg sync.WaitGroup
+
+	for _, name := range []string{"alice", "bob", "carol"} {
+		playerID, err := room.Join(name, func(string) {})
+		require.NoError(t, err)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for letter := 'a'; letter <= 'z'; letter++ {
+				_ = room.Guess(playerID, letter)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	<-room.Done()
+	assert.True(t, game.WordGuessed())
+	assert.Equal(t, 0, game.GetAttempts())
+}
+
+func TestRoom_Watch_events(t *testing.T) {
+	game, err := domain.NewGame(domain.WordHintPair{Word: "apple", Hint: "A fruit"}, "fruits", "easy")
+	require.NoError(t, err)
+
+	game.SetMaxAttempts(2)
+
+	room := application.NewRoom(game, application.RoomModeRace)
+
+	var events []domain.EventType
+
+	unwatch := room.Watch(func(event domain.GameEvent) {
+		events = append(events, event.Type)
+	})
+	defer unwatch()
+
+	player, err := room.Join("alice", func(string) {})
+	require.NoError(t, err)
+
+	require.NoError(t, room.Guess(player, 'a'))
+	require.NoError(t, room.Guess(player, 'z'))
+	require.NoError(t, room.Guess(player, 'x'))
+
+	assert.Equal(t, []domain.EventType{
+		domain.EventState,
+		domain.EventHit,
+		domain.EventMiss,
+		domain.EventHintUnlocked,
+		domain.EventMiss,
+		domain.EventLost,
+	}, events)
+}
@@ -0,0 +1,415 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/es-debug/backend-academy-2024-go-template/internal/application"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/domain"
+	"github.com/es-debug/backend-academy-2024-go-template/internal/infrastructure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock stands still until the test moves it, firing the timers it passes.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+}
+
+type fakeTimer struct {
+	at   time.Time
+	fire chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		now:     time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		waiting: make(chan struct{}, 16),
+	}
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	return clock.now
+}
+
+func (clock *fakeClock) After(d time.Duration) <-chan time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	timer := fakeTimer{at: clock.now.Add(d), fire: make(chan time.Time, 1)}
+	clock.timers = append(clock.timers, timer)
+
+	select {
+	case clock.waiting <- struct{}{}:
+	default:
+	}
+
+	return timer.fire
+}
+
+// WaitForTimer blocks until the code under test starts waiting for a timer.
+func (clock *fakeClock) WaitForTimer() {
+	<-clock.waiting
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	clock.now = clock.now.Add(d)
+
+	pending := clock.timers[:0]
+
+	for _, timer := range clock.timers {
+		if timer.at.After(clock.now) {
+			pending = append(pending, timer)
+			continue
+		}
+
+		timer.fire <- clock.now
+	}
+
+	clock.timers = pending
+}
+
+type recorderStub struct {
+	records []domain.GameRecord
+}
+
+func (recorder *recorderStub) RecordGame(record domain.GameRecord) error {
+	recorder.records = append(recorder.records, record)
+	return nil
+}
+
+func newTestService(input io.Reader, output io.Writer, clock domain.Clock) (*application.GameService, *recorderStub) {
+	return newTestServiceWithWords(input, output, clock, domain.WordHintPair{Word: "cat", Hint: "a pet"})
+}
+
+// newTestServiceWithWords returns the service playing the words of the easy animals.
+func newTestServiceWithWords(
+	input io.Reader,
+	output io.Writer,
+	clock domain.Clock,
+	words ...domain.WordHintPair,
+) (*application.GameService, *recorderStub) {
+	provider := &domain.DefaultWordProvider{
+		Words: map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+			"easy": {"animals": words},
+		},
+		AllDifficulties: []domain.Difficulty{"easy"},
+		AllCategories:   []domain.Category{"animals"},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ui := infrastructure.NewLineUI(&infrastructure.PlainRenderer{}, input, output, logger)
+	recorder := &recorderStub{}
+
+	service := application.NewGameService(provider, ui, clock, logger)
+	service.SetRecorder(recorder)
+
+	return service, recorder
+}
+
+func TestGameService_Play(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		wantResult   domain.EventType
+		wantAttempts int
+	}{
+		{
+			name:         "won",
+			input:        "c\nx\n1\na\nt\n",
+			wantResult:   domain.EventWon,
+			wantAttempts: 1,
+		},
+		{
+			name:         "lost",
+			input:        "x\ny\nz\n",
+			wantResult:   domain.EventLost,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+
+			service, recorder := newTestService(strings.NewReader(tt.input), &output, newFakeClock())
+
+			game, err := service.NewGame(application.GameSettings{Attempts: 2, HintPolicy: domain.DefaultHintPolicy})
+			require.NoError(t, err)
+			require.NoError(t, service.Play(context.Background(), game))
+
+			require.Len(t, recorder.records, 1)
+			assert.Equal(t, tt.wantResult, recorder.records[0].Result)
+			assert.Equal(t, tt.wantAttempts, recorder.records[0].Attempts)
+			assert.Equal(t, "cat", recorder.records[0].Word)
+			assert.Equal(t, 2024, recorder.records[0].FinishedAt.Year())
+			assert.Contains(t, output.String(), infrastructure.GameOverMessage(game))
+		})
+	}
+}
+
+func TestGameService_Play_inputEnds(t *testing.T) {
+	service, recorder := newTestService(strings.NewReader("c\n"), io.Discard, newFakeClock())
+
+	game, err := service.NewGame(application.GameSettings{HintPolicy: domain.DefaultHintPolicy})
+	require.NoError(t, err)
+
+	assert.Error(t, service.Play(context.Background(), game))
+	assert.Empty(t, recorder.records)
+}
+
+func TestGameService_Play_cancelled(t *testing.T) {
+	input, typing := io.Pipe()
+	defer typing.Close()
+
+	var output bytes.Buffer
+
+	service, recorder := newTestService(input, &output, newFakeClock())
+
+	game, err := domain.NewGame(domain.WordHintPair{Word: "cat", Hint: "a pet"}, "animals", "easy")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = service.Play(ctx, game)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.EventAbandoned, recorder.records[0].Result)
+	assert.Contains(t, output.String(), infrastructure.AbandonedMessage(game))
+}
+
+func TestGameService_Play_turnTimeout(t *testing.T) {
+	input, typing := io.Pipe()
+	defer typing.Close()
+
+	var output bytes.Buffer
+
+	clock := newFakeClock()
+	service, recorder := newTestService(input, &output, clock)
+
+	game, err := service.NewGame(application.GameSettings{
+		Attempts:   2,
+		HintPolicy: domain.DefaultHintPolicy,
+		TurnTime:   20 * time.Second,
+		GameTime:   time.Hour,
+	})
+	require.NoError(t, err)
+
+	played := make(chan error)
+
+	go func() {
+		played <- service.Play(context.Background(), game)
+	}()
+
+	// Each turn times out on the fake clock, long before the time limit of the game.
+	for range 2 {
+		clock.WaitForTimer()
+		clock.Advance(20 * time.Second)
+	}
+
+	require.NoError(t, <-played)
+	assert.False(t, game.IsTimeUp())
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.EventLost, recorder.records[0].Result)
+	assert.Equal(t, 2, recorder.records[0].Attempts)
+	assert.Contains(t, output.String(), infrastructure.Localize(infrastructure.MessageTurnTimedOut))
+}
+
+func TestGameService_Play_gameTimeDuringTurn(t *testing.T) {
+	input, typing := io.Pipe()
+	defer typing.Close()
+
+	clock := newFakeClock()
+	service, recorder := newTestService(input, io.Discard, clock)
+
+	game, err := service.NewGame(application.GameSettings{
+		Attempts:   5,
+		HintPolicy: domain.DefaultHintPolicy,
+		TurnTime:   20 * time.Second,
+		GameTime:   30 * time.Second,
+	})
+	require.NoError(t, err)
+
+	played := make(chan error)
+
+	go func() {
+		played <- service.Play(context.Background(), game)
+	}()
+
+	// The first turn times out, and the second one only lasts for the 10 seconds left of the game.
+	clock.WaitForTimer()
+	clock.Advance(20 * time.Second)
+	clock.WaitForTimer()
+	clock.Advance(10 * time.Second)
+
+	require.NoError(t, <-played)
+	assert.True(t, game.IsTimeUp())
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.EventLost, recorder.records[0].Result)
+	assert.Equal(t, 1, recorder.records[0].Attempts)
+}
+
+func TestGameService_Play_gameTime(t *testing.T) {
+	var output bytes.Buffer
+
+	clock := newFakeClock()
+	service, recorder := newTestService(strings.NewReader("c\n"), &output, clock)
+
+	game, err := service.NewGame(application.GameSettings{HintPolicy: domain.DefaultHintPolicy, GameTime: time.Minute})
+	require.NoError(t, err)
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 30, game.Snapshot().TimeLeft)
+
+	clock.Advance(time.Minute)
+	require.NoError(t, service.Play(context.Background(), game))
+
+	assert.True(t, game.IsTimeUp())
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.EventLost, recorder.records[0].Result)
+	assert.Equal(t, 0, recorder.records[0].Attempts)
+	assert.Contains(t, output.String(), infrastructure.GameOverMessage(game))
+}
+
+func TestGameService_NewGame_unknownCategory(t *testing.T) {
+	var output bytes.Buffer
+
+	service, _ := newTestService(strings.NewReader(""), &output, newFakeClock())
+
+	game, err := service.NewGame(application.GameSettings{Category: "animls", HintPolicy: domain.DefaultHintPolicy})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Category("animals"), game.GetCategory())
+	assert.Contains(t, output.String(), "animls")
+
+	_, err = service.NewGame(application.GameSettings{Category: "animls", Strict: true})
+
+	unknownValueErr := new(domain.UnknownValueError)
+	require.True(t, errors.As(err, &unknownValueErr))
+	assert.Equal(t, []string{"animals"}, unknownValueErr.Suggestions)
+}
+
+func TestGameService_PlayBlitz(t *testing.T) {
+	var output bytes.Buffer
+
+	// Both words are solved by the same letters, whichever of them comes first.
+	service, recorder := newTestServiceWithWords(
+		strings.NewReader("x\nc\na\nt\nc\na\nt\n"),
+		&output,
+		newFakeClock(),
+		domain.WordHintPair{Word: "cat", Hint: "a pet"},
+		domain.WordHintPair{Word: "act", Hint: "a deed"},
+	)
+
+	score, err := service.PlayBlitz(context.Background(), application.GameSettings{Attempts: 3, HintPolicy: domain.DefaultHintPolicy})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BlitzScore{Played: 2, Solved: 2}, score)
+
+	require.Len(t, recorder.records, 2)
+	assert.NotEqual(t, recorder.records[0].Word, recorder.records[1].Word)
+	assert.Equal(t, 3, recorder.records[0].MaxAttempts)
+	assert.Equal(t, 2, recorder.records[1].MaxAttempts)
+	assert.Contains(t, output.String(), infrastructure.Localize(infrastructure.MessageBlitzNext, 2))
+	assert.Contains(t, output.String(), infrastructure.Localize(infrastructure.MessageBlitzNoWords))
+	assert.Contains(t, output.String(), infrastructure.Localize(infrastructure.MessageBlitzScore, 2, 2))
+
+	// The words played are only excluded within the blitz, so the next game can pick them again.
+	_, err = service.NewGame(application.GameSettings{HintPolicy: domain.DefaultHintPolicy})
+	assert.NoError(t, err)
+
+	_, err = service.NewGame(application.GameSettings{ExcludeWords: []string{"cat", "act"}})
+
+	notFoundErr := new(domain.NotFoundError)
+	assert.True(t, errors.As(err, &notFoundErr))
+}
+
+func TestGameService_PlayBlitz_noAttemptsLeft(t *testing.T) {
+	var output bytes.Buffer
+
+	// The first word is won by the letter the hint reveals, which uses up the last attempt.
+	service, recorder := newTestServiceWithWords(
+		strings.NewReader("c\n:hint\nc\n:hint\n"),
+		&output,
+		newFakeClock(),
+		domain.WordHintPair{Word: "ca", Hint: "a pet"},
+		domain.WordHintPair{Word: "cb", Hint: "a deed"},
+	)
+
+	score, err := service.PlayBlitz(context.Background(), application.GameSettings{
+		Attempts:   1,
+		HintPolicy: domain.HintPolicy{Mode: domain.HintModeReveal},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BlitzScore{Played: 1, Solved: 1}, score)
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.EventWon, recorder.records[0].Result)
+	assert.NotContains(t, output.String(), infrastructure.Localize(infrastructure.MessageBlitzNext, 0))
+	assert.Contains(t, output.String(), infrastructure.Localize(infrastructure.MessageBlitzScore, 1, 1))
+}
+
+func TestGameService_PlayBlitz_lost(t *testing.T) {
+	var output bytes.Buffer
+
+	service, recorder := newTestServiceWithWords(
+		strings.NewReader("x\n"),
+		&output,
+		newFakeClock(),
+		domain.WordHintPair{Word: "cat", Hint: "a pet"},
+		domain.WordHintPair{Word: "act", Hint: "a deed"},
+	)
+
+	score, err := service.PlayBlitz(context.Background(), application.GameSettings{Attempts: 1, HintPolicy: domain.DefaultHintPolicy})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BlitzScore{Played: 1, Solved: 0}, score)
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.EventLost, recorder.records[0].Result)
+	assert.Contains(t, output.String(), infrastructure.Localize(infrastructure.MessageBlitzScore, 0, 1))
+}
+
+func TestGameService_logger(t *testing.T) {
+	var defaultLog, serviceLog bytes.Buffer
+
+	defer slog.SetDefault(slog.Default())
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(&defaultLog, nil)))
+
+	provider := &domain.DefaultWordProvider{
+		Words: map[domain.Difficulty]map[domain.Category][]domain.WordHintPair{
+			"easy": {"animals": {{Word: "cat", Hint: "a pet"}}},
+		},
+		AllDifficulties: []domain.Difficulty{"easy"},
+		AllCategories:   []domain.Category{"animals"},
+	}
+
+	logger := slog.New(slog.NewTextHandler(&serviceLog, nil))
+	ui := infrastructure.NewLineUI(&infrastructure.PlainRenderer{}, strings.NewReader("c\na\nt\n"), io.Discard, logger)
+	service := application.NewGameService(provider, ui, newFakeClock(), logger)
+
+	game, err := service.NewGame(application.GameSettings{Category: "animls", HintPolicy: domain.DefaultHintPolicy})
+	require.NoError(t, err)
+	require.NoError(t, service.Play(context.Background(), game))
+
+	assert.Empty(t, defaultLog.String())
+	assert.Contains(t, serviceLog.String(), "Category not found")
+	assert.Contains(t, serviceLog.String(), "User input")
+}